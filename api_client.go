@@ -1,21 +1,27 @@
 package fireblocksdk
 
 import (
+	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/pkg/errors"
 )
 
 const (
 	APIVERSION string = "v1"
+
+	idempotencyKeyHeader = "Idempotency-Key"
 )
 
 type IAPIClient interface {
@@ -25,51 +31,316 @@ type IAPIClient interface {
 	DoDeleteRequest(path string) ([]byte, int, error)
 }
 
+// IAPIClientWithContext is implemented by clients which cancel requests with ctx,
+// FireblocksSDK uses the methods of IAPIClient for clients set by WithAPIClient without it
+type IAPIClientWithContext interface {
+	DoPostRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error)
+	DoGetRequestWithContext(ctx context.Context, path string, q url.Values) ([]byte, int, error)
+	DoPutRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error)
+	DoDeleteRequestWithContext(ctx context.Context, path string) ([]byte, int, error)
+}
+
+// IAPIClientPatcher is implemented by clients which send PATCH requests,
+// endpoints using PATCH return ErrPatchNotSupported for clients without it
+type IAPIClientPatcher interface {
+	DoPatchRequest(path string, body interface{}) ([]byte, int, error)
+	DoPatchRequestWithContext(ctx context.Context, path string, body interface{}) ([]byte, int, error)
+}
+
+// ErrPatchNotSupported is returned by endpoints using PATCH when the client does not implement IAPIClientPatcher
+var ErrPatchNotSupported = errors.New("client does not implement IAPIClientPatcher")
+
+// contextClient is the client FireblocksSDK calls, see newContextClient
+type contextClient interface {
+	IAPIClient
+	IAPIClientWithContext
+	IAPIClientPatcher
+}
+
+// newContextClient adapts the client which implements only some of the optional interfaces
+func newContextClient(client IAPIClient) contextClient {
+	if full, ok := client.(contextClient); ok {
+		return full
+	}
+
+	return &compatClient{client}
+}
+
+// compatClient calls the methods of IAPIClient when the client has no context variants,
+// ctx is then checked only before the request is sent
+type compatClient struct {
+	IAPIClient
+}
+
+func (c *compatClient) DoPostRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	if client, ok := c.IAPIClient.(IAPIClientWithContext); ok {
+		return client.DoPostRequestWithContext(ctx, path, body, opts...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.DoPostRequest(path, body, opts...)
+}
+
+func (c *compatClient) DoGetRequestWithContext(ctx context.Context, path string, q url.Values) ([]byte, int, error) {
+	if client, ok := c.IAPIClient.(IAPIClientWithContext); ok {
+		return client.DoGetRequestWithContext(ctx, path, q)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.DoGetRequest(path, q)
+}
+
+// DoPutRequestWithContext drops opts when the client has no context variants, its DoPutRequest takes none
+func (c *compatClient) DoPutRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	if client, ok := c.IAPIClient.(IAPIClientWithContext); ok {
+		return client.DoPutRequestWithContext(ctx, path, body, opts...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.DoPutRequest(path, body)
+}
+
+func (c *compatClient) DoDeleteRequestWithContext(ctx context.Context, path string) ([]byte, int, error) {
+	if client, ok := c.IAPIClient.(IAPIClientWithContext); ok {
+		return client.DoDeleteRequestWithContext(ctx, path)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.DoDeleteRequest(path)
+}
+
+func (c *compatClient) DoPatchRequest(path string, body interface{}) ([]byte, int, error) {
+	return c.DoPatchRequestWithContext(context.Background(), path, body)
+}
+
+func (c *compatClient) DoPatchRequestWithContext(ctx context.Context, path string, body interface{}) ([]byte, int, error) {
+	client, ok := c.IAPIClient.(IAPIClientPatcher)
+	if !ok {
+		return nil, 0, ErrPatchNotSupported
+	}
+
+	if _, ok := c.IAPIClient.(IAPIClientWithContext); ok {
+		return client.DoPatchRequestWithContext(ctx, path, body)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return client.DoPatchRequest(path, body)
+}
+
 type APIClient struct {
 	httpClient *retryablehttp.Client
 	auth       IAuthProvider
 	baseURL    string
 }
 
-func NewAPIClient(auth IAuthProvider, baseURL string) *APIClient {
+type APIClientConfig struct {
+	// HTTPClient is the base client, cleanhttp.DefaultPooledClient when nil, it is copied and never modified
+	HTTPClient *http.Client
+	// Transport replaces the transport of HTTPClient, e.g. for proxies, mTLS or custom CA
+	Transport http.RoundTripper
+	// Pool configures connections of *http.Transport, zero fields keep the values of the transport
+	Pool ConnectionPool
+	// Timeout limits every attempt, including reading the response body, 0 keeps the timeout of HTTPClient
+	Timeout time.Duration
+
+	// RetryMax is the number of retries after the first attempt
+	RetryMax int
+	// RetryWaitMin and RetryWaitMax bound the wait between attempts, Retry-After is honored up to the max
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// RateLimits are client side limits per endpoint class, classes without the limit are not throttled
+	RateLimits map[EndpointClass]RateLimit
+	// Classifier assigns the endpoint class to the request, DefaultEndpointClassifier when nil
+	Classifier EndpointClassifier
+	// CheckRetry decides whether the failed attempt is repeated, RetryPolicy when nil
+	CheckRetry retryablehttp.CheckRetry
+	// Backoff decides how long to wait before the next attempt, RetryAfterBackoff when nil
+	Backoff retryablehttp.Backoff
+
+	// Logger receives logs of the client and retryablehttp, nil disables logging
+	Logger *slog.Logger
+}
+
+// ConnectionPool configures reuse of connections to Fireblocks
+type ConnectionPool struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+}
+
+func (p ConnectionPool) apply(transport *http.Transport) {
+	if p.MaxIdleConns > 0 {
+		transport.MaxIdleConns = p.MaxIdleConns
+	}
+
+	if p.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = p.MaxIdleConnsPerHost
+	}
+
+	if p.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = p.MaxConnsPerHost
+	}
+
+	if p.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = p.IdleConnTimeout
+	}
+}
+
+// DefaultAPIClientConfig returns the retry settings of retryablehttp.NewClient and no rate limits
+func DefaultAPIClientConfig() *APIClientConfig {
+	return &APIClientConfig{
+		RetryMax:     4,
+		RetryWaitMin: time.Second,
+		RetryWaitMax: 30 * time.Second,
+		RateLimits:   map[EndpointClass]RateLimit{},
+		CheckRetry:   RetryPolicy,
+		Backoff:      RetryAfterBackoff,
+		Logger:       slog.Default(),
+	}
+}
+
+func NewAPIClient(auth IAuthProvider, baseURL string, configs ...func(*APIClientConfig)) *APIClient {
+	cfg := DefaultAPIClientConfig()
+	for _, conf := range configs {
+		conf(cfg)
+	}
+
+	if cfg.CheckRetry == nil {
+		cfg.CheckRetry = RetryPolicy
+	}
+
+	if cfg.Backoff == nil {
+		cfg.Backoff = RetryAfterBackoff
+	}
+
 	client := retryablehttp.NewClient()
+	client.HTTPClient = newHTTPClient(cfg)
+	client.RetryMax = cfg.RetryMax
+	client.RetryWaitMin = cfg.RetryWaitMin
+	client.RetryWaitMax = cfg.RetryWaitMax
+	client.CheckRetry = cfg.CheckRetry
+	client.Backoff = cfg.Backoff
+	// return the last response when retries are exhausted, so it can be turned into APIError
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	// default logger of retryablehttp writes to stderr, the typed nil would be taken for the logger
+	client.Logger = nil
+	if cfg.Logger != nil {
+		client.Logger = cfg.Logger
+	}
 
 	return &APIClient{client, auth, baseURL}
 }
 
-func (api *APIClient) makeRequest(method, path string, body interface{}) ([]byte, int, error) {
+func newHTTPClient(cfg *APIClientConfig) *http.Client {
+	httpClient := cleanhttp.DefaultPooledClient()
+	if cfg.HTTPClient != nil {
+		copied := *cfg.HTTPClient
+		httpClient = &copied
+	}
+
+	if cfg.Transport != nil {
+		httpClient.Transport = cfg.Transport
+	}
+
+	if httpClient.Transport == nil {
+		httpClient.Transport = cleanhttp.DefaultPooledTransport()
+	}
+
+	// the transport may be shared with other clients, the pool is configured on the clone
+	if transport, ok := httpClient.Transport.(*http.Transport); ok && cfg.Pool != (ConnectionPool{}) {
+		transport = transport.Clone()
+		cfg.Pool.apply(transport)
+		httpClient.Transport = transport
+	}
+
+	if cfg.Timeout > 0 {
+		httpClient.Timeout = cfg.Timeout
+	}
+
+	if cfg.Logger != nil {
+		httpClient.Transport = &loggingTransport{next: httpClient.Transport, logger: cfg.Logger}
+	}
+
+	httpClient.Transport = &signingTransport{next: httpClient.Transport}
+	httpClient.Transport = &attemptTransport{next: httpClient.Transport}
+
+	if len(cfg.RateLimits) > 0 {
+		httpClient.Transport = newRateLimitTransport(httpClient.Transport, cfg.Classifier, cfg.RateLimits)
+	}
+
+	return httpClient
+}
+
+func (api *APIClient) makeRequest(ctx context.Context, method, path string, body interface{}, header http.Header) ([]byte, int, error) {
 	var (
 		status     = http.StatusInternalServerError
 		bodyJSON   = []byte("")
 		requestErr error
 	)
 
-	if method != http.MethodGet && body != nil {
+	if method != http.MethodGet && method != http.MethodDelete && body != nil {
 		var err error
 		bodyJSON, err = json.Marshal(body)
 		if err != nil {
 			return nil, status, errors.Wrap(err, "failed to marshal body")
 		}
-	}
 
-	jwtToken, err := api.auth.SignJwt(path, bodyJSON)
-	if err != nil {
-		return nil, status, errors.Wrap(err, "failed to sign request")
+		// empty object is sent as empty body, bodyHash must be computed over the bytes actually sent
+		if string(bodyJSON) == "{}" {
+			bodyJSON = []byte("")
+		}
 	}
 
+	// nonce and exp of the token are single use, every attempt is signed by signingTransport
+	// after the rate limiter lets it through, so waiting for the limit can't expire the token
+	uri := path
+	ctx = withRequestSigner(ctx, func() (string, error) {
+		return api.auth.SignJwt(uri, bodyJSON)
+	})
+
 	path = fmt.Sprintf("%s%s", api.baseURL, path)
+	ctx = withAttempts(ctx)
 
-	req, err := retryablehttp.NewRequest(method, path, prepareBody(bodyJSON))
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, path, prepareBody(bodyJSON))
 	if err != nil {
 		return nil, status, errors.Wrap(err, "failed to create request")
 	}
 
 	req.Header.Add("X-API-Key", api.auth.GetAPIKey())
-	req.Header.Add("Authorization", fmt.Sprintf(`Bearer %s`, jwtToken))
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
 	resp, err := api.httpClient.Do(req)
 	if err != nil {
+		// PassthroughErrorHandler returns the last response together with the error, e.g. when ctx
+		// is done after the response arrived, the body is drained so the connection can be reused
+		if resp != nil && resp.Body != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
 		return nil, status, errors.Wrapf(err, "failed to do request: %s", path)
 	}
 
@@ -79,14 +350,6 @@ func (api *APIClient) makeRequest(method, path string, body interface{}) ([]byte
 		defer resp.Body.Close()
 
 		status = resp.StatusCode
-		log.Printf(
-			"[%s] %s: status: %s, code: %d, content-length: %d",
-			method,
-			path,
-			resp.Status,
-			resp.StatusCode,
-			resp.ContentLength,
-		)
 
 		responseBody, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
@@ -95,13 +358,8 @@ func (api *APIClient) makeRequest(method, path string, body interface{}) ([]byte
 
 		result = responseBody
 
-		if status >= 400 {
-			sdkErr := &sdkError{}
-			errM := json.Unmarshal(result, sdkErr)
-			if errM == nil {
-				sdkErr.Path = fmt.Sprintf("%s %s", method, path)
-				requestErr = sdkErr
-			}
+		if status >= http.StatusBadRequest {
+			requestErr = newAPIError(method, path, resp, result)
 		}
 	}
 
@@ -109,33 +367,142 @@ func (api *APIClient) makeRequest(method, path string, body interface{}) ([]byte
 }
 
 func (api *APIClient) DoPostRequest(path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	return api.DoPostRequestWithContext(context.Background(), path, body, opts...)
+}
+
+func (api *APIClient) DoGetRequest(path string, q url.Values) ([]byte, int, error) {
+	return api.DoGetRequestWithContext(context.Background(), path, q)
+}
+
+func (api *APIClient) DoPutRequest(path string, body interface{}) ([]byte, int, error) {
+	return api.DoPutRequestWithContext(context.Background(), path, body)
+}
+
+func (api *APIClient) DoPatchRequest(path string, body interface{}) ([]byte, int, error) {
+	return api.DoPatchRequestWithContext(context.Background(), path, body)
+}
+
+func (api *APIClient) DoDeleteRequest(path string) ([]byte, int, error) {
+	return api.DoDeleteRequestWithContext(context.Background(), path)
+}
+
+// DoPostRequestWithContext sends POST request, ctx cancels the request together with pending retries
+// The same Idempotency-Key header is sent on every retry attempt, a random key is generated
+// when the caller did not provide one with WithIdempotencyKey.
+func (api *APIClient) DoPostRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	option := &PostRequestOption{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	if option.idempotencyKey == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		option.idempotencyKey = key
+	}
+
+	header := http.Header{}
+	header.Set(idempotencyKeyHeader, option.idempotencyKey)
+
 	path = api.GetRelativePath(path)
 
-	return api.makeRequest(http.MethodPost, path, body)
+	return api.makeRequest(ctx, http.MethodPost, path, body, header)
 }
 
-func (api *APIClient) DoGetRequest(path string, q url.Values) ([]byte, int, error) {
-	query := ""
+// DoGetRequestWithContext sends GET request, ctx cancels the request together with pending retries
+// The query is signed as part of the uri claim, bodyHash of GET request is the hash of the empty body.
+func (api *APIClient) DoGetRequestWithContext(ctx context.Context, path string, q url.Values) ([]byte, int, error) {
 	path = api.GetRelativePath(path)
 
-	if q != nil {
-		query = q.Encode()
+	if query := CanonicalQuery(q); query != "" {
 		path = fmt.Sprintf(`%s?%s`, path, query)
 	}
 
-	return api.makeRequest(http.MethodGet, path, []byte(query))
+	return api.makeRequest(ctx, http.MethodGet, path, nil, nil)
 }
 
-func (api *APIClient) DoPutRequest(path string, body interface{}) ([]byte, int, error) {
+// DoPutRequestWithContext sends PUT request, ctx cancels the request together with pending retries
+// Idempotency-Key header is sent when the caller provides one with WithIdempotencyKey,
+// PUT is idempotent, so no key is generated otherwise.
+func (api *APIClient) DoPutRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	option := &PostRequestOption{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	var header http.Header
+	if option.idempotencyKey != "" {
+		header = http.Header{}
+		header.Set(idempotencyKeyHeader, option.idempotencyKey)
+	}
+
 	path = api.GetRelativePath(path)
 
-	return api.makeRequest(http.MethodPut, path, body)
+	return api.makeRequest(ctx, http.MethodPut, path, body, header)
 }
 
-func (api *APIClient) DoDeleteRequest(path string) ([]byte, int, error) {
+// DoPatchRequestWithContext sends PATCH request, ctx cancels the request together with pending retries
+func (api *APIClient) DoPatchRequestWithContext(ctx context.Context, path string, body interface{}) ([]byte, int, error) {
+	path = api.GetRelativePath(path)
+
+	return api.makeRequest(ctx, http.MethodPatch, path, body, nil)
+}
+
+// DoDeleteRequestWithContext sends DELETE request, ctx cancels the request together with pending retries
+func (api *APIClient) DoDeleteRequestWithContext(ctx context.Context, path string) ([]byte, int, error) {
 	path = api.GetRelativePath(path)
 
-	return api.makeRequest(http.MethodDelete, path, nil)
+	return api.makeRequest(ctx, http.MethodDelete, path, nil, nil)
+}
+
+type requestSignerKey struct{}
+
+// withRequestSigner stores the function which signs the JWT of the request for signingTransport
+func withRequestSigner(ctx context.Context, sign func() (string, error)) context.Context {
+	return context.WithValue(ctx, requestSignerKey{}, sign)
+}
+
+// signingError is returned by signingTransport, RetryPolicy does not retry it
+type signingError struct {
+	err error
+}
+
+func (e *signingError) Error() string {
+	return fmt.Sprintf("failed to sign request: %s", e.err)
+}
+
+func (e *signingError) Unwrap() error {
+	return e.err
+}
+
+// signingTransport signs every attempt with the new token, it runs inside rateLimitTransport,
+// so the token is signed only after the wait for the rate limit
+type signingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sign, ok := req.Context().Value(requestSignerKey{}).(func() (string, error))
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	jwtToken, err := sign()
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+
+		return nil, &signingError{err}
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf(`Bearer %s`, jwtToken))
+
+	return t.next.RoundTrip(req)
 }
 
 // GetRelativePath returns path without baseURL
@@ -143,11 +510,26 @@ func (api *APIClient) GetRelativePath(path string) string {
 	return fmt.Sprintf(`/%s%s`, APIVERSION, path)
 }
 
-func prepareBody(encodedBody []byte) io.ReadCloser {
-	if string(encodedBody) == "{}" {
-		encodedBody = []byte("")
+// newIdempotencyKey generates random UUID v4
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate idempotency key")
 	}
 
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// CanonicalQuery encodes q with keys sorted and every reserved character percent-encoded,
+// space is encoded as %20, so the uri claim is exactly the request URI sent on the wire
+func CanonicalQuery(q url.Values) string {
+	return strings.ReplaceAll(q.Encode(), "+", "%20")
+}
+
+func prepareBody(encodedBody []byte) io.ReadCloser {
 	return ioutil.NopCloser(
 		strings.NewReader(
 			string(encodedBody),
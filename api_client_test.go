@@ -1 +1,369 @@
 package fireblocksdk_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAPIClientSuite(t *testing.T) {
+	suite.Run(t, new(APIClientSuite))
+}
+
+type APIClientSuite struct {
+	suite.Suite
+	auth sdk.IAuthProvider
+}
+
+func (suite *APIClientSuite) SetupTest() {
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey))
+	require.NoError(suite.T(), err)
+
+	suite.auth = auth
+}
+
+func (suite *APIClientSuite) TestContextCancelsRetries() {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := sdk.NewAPIClient(suite.auth, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, _, err := client.DoGetRequestWithContext(ctx, "/supported_assets", nil)
+	require.Error(suite.T(), err)
+	require.ErrorIs(suite.T(), err, context.DeadlineExceeded)
+	require.Less(suite.T(), time.Since(started), time.Second)
+	require.Equal(suite.T(), int32(1), atomic.LoadInt32(&calls))
+}
+
+func (suite *APIClientSuite) TestContextCancelsInFlightRequest() {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL)
+	require.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = fb.GetVaultAccountsByIDWithContext(ctx, "1")
+	require.Error(suite.T(), err)
+	require.ErrorIs(suite.T(), err, context.Canceled)
+}
+
+func (suite *APIClientSuite) TestIdempotencyKeySurvivesRetries() {
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":"tx-1","status":"SUBMITTED"}`))
+	}))
+	defer server.Close()
+
+	client := sdk.NewAPIClient(suite.auth, server.URL)
+
+	_, status, err := client.DoPostRequest("/transactions", map[string]string{}, sdk.WithIdempotencyKey("key-1"))
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), http.StatusOK, status)
+	require.Equal(suite.T(), []string{"key-1", "key-1"}, keys)
+}
+
+func (suite *APIClientSuite) TestIdempotencyKeyIsGeneratedForPost() {
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := sdk.NewAPIClient(suite.auth, server.URL)
+
+	_, _, err := client.DoPostRequest("/transactions", nil)
+	require.NoError(suite.T(), err)
+	_, _, err = client.DoPostRequest("/transactions", nil)
+	require.NoError(suite.T(), err)
+
+	require.Len(suite.T(), keys, 3)
+	require.NotEmpty(suite.T(), keys[0])
+	require.Equal(suite.T(), keys[0], keys[1], "retry must reuse the generated key")
+	require.NotEqual(suite.T(), keys[1], keys[2], "every call must get a new key")
+}
+
+func (suite *APIClientSuite) TestIdempotencyKeyIsNotSentForGet() {
+	var key string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := sdk.NewAPIClient(suite.auth, server.URL)
+
+	_, _, err := client.DoGetRequest("/transactions", nil)
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), key)
+}
+
+func (suite *APIClientSuite) TestHTTPTimeout() {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL,
+		sdk.WithHTTPTimeout(50*time.Millisecond),
+		sdk.WithRetryMax(0),
+	)
+	require.NoError(suite.T(), err)
+
+	started := time.Now()
+	_, err = fb.GetSupportedAssets()
+	require.Error(suite.T(), err)
+	require.Less(suite.T(), time.Since(started), time.Second)
+}
+
+func (suite *APIClientSuite) TestHTTPTimeoutInMilliseconds() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL,
+		sdk.WithHTTPTimout(1000),
+		sdk.WithRetryMax(0),
+	)
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssets()
+	require.NoError(suite.T(), err)
+}
+
+func (suite *APIClientSuite) TestClientOptionsWithAPIClient() {
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey))
+	require.NoError(suite.T(), err)
+
+	client := sdk.NewAPIClient(auth, "")
+
+	for _, opt := range []func(*sdk.SDKOptions){
+		sdk.WithHTTPTimeout(time.Second),
+		sdk.WithHTTPTimout(1000),
+		sdk.WithRetryMax(0),
+		sdk.WithRateLimit(sdk.EndpointClassRead, 1, 1),
+	} {
+		_, err = sdk.CreateSDK("apiKey", []byte(privateKey), "", sdk.WithAPIClient(client), opt)
+		require.ErrorIs(suite.T(), err, sdk.ErrClientOptionsWithAPIClient)
+	}
+}
+
+func (suite *APIClientSuite) TestRetryMaxAndWait() {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL,
+		sdk.WithRetryMax(2),
+		sdk.WithRetryWait(50*time.Millisecond, 50*time.Millisecond),
+	)
+	require.NoError(suite.T(), err)
+
+	started := time.Now()
+	_, err = fb.GetSupportedAssets()
+	require.Error(suite.T(), err)
+	require.Equal(suite.T(), int32(3), atomic.LoadInt32(&calls))
+	require.GreaterOrEqual(suite.T(), time.Since(started), 100*time.Millisecond)
+	require.Less(suite.T(), time.Since(started), time.Second)
+}
+
+type headerTransport struct {
+	next  http.RoundTripper
+	calls int32
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	req.Header.Set("X-Proxy", "proxied")
+
+	return t.next.RoundTrip(req)
+}
+
+// cancelTransport cancels the request after its response arrived and records whether the body was closed
+type cancelTransport struct {
+	next   http.RoundTripper
+	cancel context.CancelFunc
+	closed int32
+}
+
+func (t *cancelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		resp.Body = &closeRecorder{resp.Body, &t.closed}
+	}
+
+	t.cancel()
+
+	return resp, err
+}
+
+type closeRecorder struct {
+	io.ReadCloser
+	closed *int32
+}
+
+func (r *closeRecorder) Close() error {
+	atomic.StoreInt32(r.closed, 1)
+	return r.ReadCloser.Close()
+}
+
+func (suite *APIClientSuite) TestBodyIsClosedWhenContextIsDoneAfterResponse() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport := &cancelTransport{next: http.DefaultTransport, cancel: cancel}
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, sdk.WithTransport(transport))
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssetsWithContext(ctx)
+	require.ErrorIs(suite.T(), err, context.Canceled)
+	require.Equal(suite.T(), int32(1), atomic.LoadInt32(&transport.closed))
+}
+
+func (suite *APIClientSuite) TestCustomTransport() {
+	var header string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Proxy")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	transport := &headerTransport{next: http.DefaultTransport}
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, sdk.WithTransport(transport))
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssets()
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "proxied", header)
+	require.Equal(suite.T(), int32(1), atomic.LoadInt32(&transport.calls))
+}
+
+func (suite *APIClientSuite) TestCustomHTTPClient() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	transport := &headerTransport{next: http.DefaultTransport}
+	httpClient := &http.Client{Transport: transport}
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL,
+		sdk.WithHTTPClient(httpClient),
+		sdk.WithHTTPTimeout(time.Second),
+	)
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssets()
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int32(1), atomic.LoadInt32(&transport.calls))
+	require.Zero(suite.T(), httpClient.Timeout, "the client of the caller must not be modified")
+}
+
+func (suite *APIClientSuite) TestConnectionPool() {
+	var (
+		mu          sync.Mutex
+		active, max int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > max {
+			max = active
+		}
+		mu.Unlock()
+
+		time.Sleep(30 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL,
+		sdk.WithConnectionPool(sdk.ConnectionPool{MaxConnsPerHost: 1}),
+	)
+	require.NoError(suite.T(), err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := fb.GetSupportedAssets()
+			suite.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(suite.T(), 1, max)
+}
@@ -28,6 +28,7 @@ type IAuthProvider interface {
 
 type AuthProviderConfig struct {
 	timeProvider  ITimeProvider
+	nonceProvider INonceProvider
 	expirySeconds int64
 }
 
@@ -45,6 +46,14 @@ func WithTokenExpiry(exp int64) func(c *AuthProviderConfig) error {
 	}
 }
 
+func WithNonceProvider(np INonceProvider) func(c *AuthProviderConfig) error {
+	return func(c *AuthProviderConfig) error {
+		c.nonceProvider = np
+
+		return nil
+	}
+}
+
 func WithTimeProvider(tp ITimeProvider) func(c *AuthProviderConfig) error {
 	return func(c *AuthProviderConfig) error {
 		c.timeProvider = tp
@@ -55,7 +64,7 @@ func WithTimeProvider(tp ITimeProvider) func(c *AuthProviderConfig) error {
 
 // NewAuthProvider Creates signer using api key and private key from config
 func NewAuthProvider(apiKey string, apiSecretKey []byte, configs ...func(*AuthProviderConfig) error) (*AuthProvider, error) {
-	cfg := &AuthProviderConfig{DefaultTimeProvider(), DefaultTokenExpiry()}
+	cfg := &AuthProviderConfig{DefaultTimeProvider(), DefaultNonceProvider(), DefaultTokenExpiry()}
 	for _, conf := range configs {
 		err := conf(cfg)
 		if err != nil {
@@ -71,7 +80,7 @@ func NewAuthProvider(apiKey string, apiSecretKey []byte, configs ...func(*AuthPr
 	auth := &AuthProvider{
 		apiKey,
 		key,
-		DefaultClaimProvider(cfg.timeProvider, cfg.expirySeconds),
+		NewFireblocksClaimsProviderWithNonce(cfg.timeProvider, cfg.nonceProvider, cfg.expirySeconds),
 	}
 
 	return auth, nil
@@ -94,3 +94,12 @@ type AssetResponse struct {
 	TotalStakedCPU       *string             `json:"totalStakedCPU,omitempty"`     // Deprecated
 	TotalStakedNetwork   *string             `json:"totalStakedNetwork,omitempty"` // Deprecated
 }
+
+type CreateVaultAssetRequest struct {
+	EosAccountName string `json:"eosAccountName,omitempty"` // [optional] Existing EOS account name, a new account is created otherwise
+}
+
+// MaxSpendableAmountResponse defines model for MaxSpendableAmount.
+type MaxSpendableAmountResponse struct {
+	MaxSpendableAmount string `json:"maxSpendableAmount,omitempty"` // The maximum amount which can be spent in a single transaction
+}
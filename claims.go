@@ -12,11 +12,18 @@ type IFireblocksClaims interface {
 
 type FireblocksClaims struct {
 	time          ITimeProvider
+	nonce         INonceProvider
 	expirySeconds int64
 }
 
 func NewFireblocksClaimsProvider(timeProvider ITimeProvider, exp int64) IFireblocksClaims {
-	return &FireblocksClaims{timeProvider, exp}
+	return NewFireblocksClaimsProviderWithNonce(timeProvider, DefaultNonceProvider(), exp)
+}
+
+// NewFireblocksClaimsProviderWithNonce creates claims with nonces from nonceProvider,
+// Fireblocks rejects the token which repeats the nonce of the previous one
+func NewFireblocksClaimsProviderWithNonce(timeProvider ITimeProvider, nonceProvider INonceProvider, exp int64) IFireblocksClaims {
+	return &FireblocksClaims{timeProvider, nonceProvider, exp}
 }
 
 func (client *FireblocksClaims) CreateClaims(path, apiKey, hash string) jwt.MapClaims {
@@ -26,7 +33,7 @@ func (client *FireblocksClaims) CreateClaims(path, apiKey, hash string) jwt.MapC
 
 	return jwt.MapClaims{
 		"uri":      path,
-		"nonce":    nowUnix,
+		"nonce":    client.nonce.Nonce(),
 		"iat":      nowUnix,
 		"now":      nowUnix,
 		"exp":      exp.Unix(),
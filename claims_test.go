@@ -2,9 +2,12 @@ package fireblocksdk_test
 
 import (
 	sdk "fireblocksdk"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/suite"
@@ -48,5 +51,82 @@ func (suite *TestClaimsSuite) TestClaimsShouldBeOnTimeDependent() {
 	claims1 := suite.claims.CreateClaims("path", "apikey", "hash")
 	time.Sleep(time.Millisecond)
 	claims2 := suite.claims.CreateClaims("path", "apikey", "hash")
+	require.NotEqual(suite.T(), claims2["nonce"], claims1["nonce"])
+
+	delete(claims1, "nonce")
+	delete(claims2, "nonce")
 	require.Equal(suite.T(), claims2, claims1)
 }
+
+func (suite *TestClaimsSuite) TestNoncesAreUniqueUnderConcurrency() {
+	providers := map[string]sdk.INonceProvider{
+		"random":    &sdk.RandomNonceProvider{},
+		"monotonic": &sdk.MonotonicNonceProvider{},
+	}
+
+	for name, nonceProvider := range providers {
+		claims := sdk.NewFireblocksClaimsProviderWithNonce(suite.time, nonceProvider, sdk.DefaultTokenExpiry())
+		nonces := make(chan interface{}, 8*1000)
+
+		var wg sync.WaitGroup
+		for worker := 0; worker < 8; worker++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					nonces <- claims.CreateClaims("path", "apikey", "hash")["nonce"]
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(nonces)
+
+		seen := map[interface{}]bool{}
+		for nonce := range nonces {
+			require.False(suite.T(), seen[nonce], "%s nonce %v repeated", name, nonce)
+			seen[nonce] = true
+		}
+
+		require.Len(suite.T(), seen, 8*1000, name)
+	}
+}
+
+func TestSignedTokensHaveUniqueNonces(t *testing.T) {
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey))
+	require.NoError(t, err)
+
+	const workers, perWorker = 8, 500
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		nonces = map[string]bool{}
+	)
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				token, err := auth.SignJwt("/v1/transactions", nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				claims := jwt.MapClaims{}
+				_, _, err = new(jwt.Parser).ParseUnverified(token, claims)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				nonces[claims["nonce"].(string)] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	require.Len(t, nonces, workers*perWorker)
+}
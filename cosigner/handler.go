@@ -0,0 +1,215 @@
+package cosigner
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+const (
+	TransactionSignPath  = "/v2/tx_sign_request"
+	ConfigChangeSignPath = "/v2/config_change_sign_request"
+
+	defaultMaxBodySize = 1 << 20
+)
+
+var ErrInvalidToken = errors.New("invalid co-signer token")
+
+// PolicyFunc decides whether the Co-Signer should sign the request
+type PolicyFunc func(ctx context.Context, req *Request) (Decision, error)
+
+type HandlerConfig struct {
+	maxBodySize int64
+	onError     func(r *http.Request, err error)
+}
+
+// WithMaxBodySize limits the size of accepted requests
+func WithMaxBodySize(size int64) func(*HandlerConfig) {
+	return func(c *HandlerConfig) {
+		c.maxBodySize = size
+	}
+}
+
+// WithErrorHandler is called for every rejected request and every failed policy call
+func WithErrorHandler(fn func(r *http.Request, err error)) func(*HandlerConfig) {
+	return func(c *HandlerConfig) {
+		c.onError = fn
+	}
+}
+
+// Handler is http.Handler serving the API Co-Signer callback endpoints.
+// Requests are JWTs signed by the Co-Signer, responses are JWTs signed by the callback key.
+type Handler struct {
+	cosignerKey *rsa.PublicKey
+	signer      crypto.Signer
+	policy      PolicyFunc
+	cfg         *HandlerConfig
+}
+
+// NewHandler Creates handler verifying requests with cosignerKey and signing responses with signer.
+// signer must hold the RSA private key which public part is configured in the Co-Signer,
+// *rsa.PrivateKey and fireblocksdk.SoftwareSigner can be used as well as HSM and KMS backed signers.
+func NewHandler(cosignerKey *rsa.PublicKey, signer crypto.Signer, policy PolicyFunc, configs ...func(*HandlerConfig)) (*Handler, error) {
+	if cosignerKey == nil || signer == nil || policy == nil {
+		return nil, errors.New("co-signer key, signer and policy are required")
+	}
+
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return nil, errors.Wrapf(jwt.ErrInvalidKeyType, "signer must hold RSA key, got %T", signer.Public())
+	}
+
+	cfg := &HandlerConfig{maxBodySize: defaultMaxBodySize}
+	for _, conf := range configs {
+		conf(cfg)
+	}
+
+	return &Handler{cosignerKey, signer, policy, cfg}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestType RequestType
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, TransactionSignPath):
+		requestType = RequestTypeTransaction
+	case strings.HasSuffix(r.URL.Path, ConfigChangeSignPath):
+		requestType = RequestTypeConfigChange
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, h.cfg.maxBodySize))
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		h.fail(w, r, status, errors.Wrap(err, "failed to read body"))
+		return
+	}
+
+	req, err := h.ParseRequest(requestType, strings.TrimSpace(string(body)))
+	if err != nil {
+		h.fail(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	decision, err := h.policy(r.Context(), req)
+	if err != nil {
+		h.fail(w, r, http.StatusInternalServerError, errors.Wrapf(err, "policy failed for request %s", req.RequestID))
+		return
+	}
+
+	token, err := h.SignResponse(req.RequestID, decision)
+	if err != nil {
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(token))
+}
+
+// ParseRequest verifies the token signed by the Co-Signer and decodes its payload
+func (h *Handler) ParseRequest(requestType RequestType, token string) (*Request, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, errors.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+
+		return h.cosignerKey, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidToken, err.Error())
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read claims")
+	}
+
+	req := &Request{Type: requestType}
+
+	switch requestType {
+	case RequestTypeTransaction:
+		req.Transaction = &TransactionSignRequest{}
+		err = json.Unmarshal(payload, req.Transaction)
+		req.RequestID = req.Transaction.RequestID
+	case RequestTypeConfigChange:
+		req.ConfigChange = &ConfigChangeSignRequest{}
+		err = json.Unmarshal(payload, req.ConfigChange)
+		req.RequestID = req.ConfigChange.RequestID
+	default:
+		return nil, errors.Errorf("unsupported request type %s", requestType)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s request", requestType)
+	}
+
+	if req.RequestID == "" {
+		return nil, errors.Wrap(ErrInvalidToken, "requestId is missing")
+	}
+
+	return req, nil
+}
+
+// SignResponse creates the RS256 token answering the request
+func (h *Handler) SignResponse(requestID string, decision Decision) (string, error) {
+	switch decision.Action {
+	case ActionApprove, ActionReject, ActionIgnore:
+	default:
+		return "", errors.Errorf("unsupported action %q", decision.Action)
+	}
+
+	claims := jwt.MapClaims{
+		"action":    decision.Action,
+		"requestId": requestID,
+	}
+
+	if decision.Action == ActionReject && decision.RejectionReason != "" {
+		claims["rejectionReason"] = decision.RejectionReason
+	}
+
+	signingString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SigningString()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create token")
+	}
+
+	digest := sha256.Sum256([]byte(signingString))
+
+	signature, err := h.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign token")
+	}
+
+	return signingString + "." + jwt.EncodeSegment(signature), nil
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.cfg.onError != nil {
+		h.cfg.onError(r, err)
+	}
+
+	w.WriteHeader(status)
+}
@@ -0,0 +1,185 @@
+package cosigner_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fireblocksdk/cosigner"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestHandlerSuite(t *testing.T) {
+	suite.Run(t, new(HandlerSuite))
+}
+
+type HandlerSuite struct {
+	suite.Suite
+	cosignerKey *rsa.PrivateKey
+	callbackKey *rsa.PrivateKey
+	policy      cosigner.PolicyFunc
+	handler     *cosigner.Handler
+	received    *cosigner.Request
+}
+
+func (suite *HandlerSuite) SetupTest() {
+	var err error
+
+	suite.cosignerKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(suite.T(), err)
+	suite.callbackKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(suite.T(), err)
+
+	suite.received = nil
+	suite.policy = func(ctx context.Context, req *cosigner.Request) (cosigner.Decision, error) {
+		suite.received = req
+
+		if req.Transaction != nil && req.Transaction.AmountStr == "1000" {
+			return cosigner.Reject("amount is over the limit"), nil
+		}
+
+		return cosigner.Approve(), nil
+	}
+
+	suite.handler, err = cosigner.NewHandler(
+		&suite.cosignerKey.PublicKey,
+		suite.callbackKey,
+		func(ctx context.Context, req *cosigner.Request) (cosigner.Decision, error) {
+			return suite.policy(ctx, req)
+		},
+	)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *HandlerSuite) request(key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(suite.T(), err)
+
+	return token
+}
+
+func (suite *HandlerSuite) send(path, body string) (int, jwt.MapClaims) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	suite.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		return rec.Code, nil
+	}
+
+	response, err := ioutil.ReadAll(rec.Body)
+	require.NoError(suite.T(), err)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(string(response), claims, func(t *jwt.Token) (interface{}, error) {
+		return &suite.callbackKey.PublicKey, nil
+	})
+	require.NoError(suite.T(), err)
+
+	return rec.Code, claims
+}
+
+func (suite *HandlerSuite) TestApprovesTransaction() {
+	token := suite.request(suite.cosignerKey, jwt.MapClaims{
+		"requestId":  "request-1",
+		"txId":       "tx-1",
+		"operation":  "TRANSFER",
+		"asset":      "BTC",
+		"amountStr":  "0.5",
+		"sourceType": "VAULT",
+		"sourceId":   "0",
+		"destinations": []map[string]interface{}{
+			{"dstAddress": "bc1q", "amountNativeStr": "0.5", "dstType": "ONE_TIME"},
+		},
+	})
+
+	status, claims := suite.send(cosigner.TransactionSignPath, token)
+	require.Equal(suite.T(), http.StatusOK, status)
+	require.Equal(suite.T(), "APPROVE", claims["action"])
+	require.Equal(suite.T(), "request-1", claims["requestId"])
+	require.NotContains(suite.T(), claims, "rejectionReason")
+
+	require.Equal(suite.T(), cosigner.RequestTypeTransaction, suite.received.Type)
+	require.Equal(suite.T(), "tx-1", suite.received.Transaction.TxID)
+	require.Equal(suite.T(), "bc1q", suite.received.Transaction.Destinations[0].DstAddress)
+	require.Nil(suite.T(), suite.received.ConfigChange)
+}
+
+func (suite *HandlerSuite) TestRejectsTransaction() {
+	token := suite.request(suite.cosignerKey, jwt.MapClaims{"requestId": "request-2", "txId": "tx-2", "amountStr": "1000"})
+
+	status, claims := suite.send(cosigner.TransactionSignPath, token)
+	require.Equal(suite.T(), http.StatusOK, status)
+	require.Equal(suite.T(), "REJECT", claims["action"])
+	require.Equal(suite.T(), "amount is over the limit", claims["rejectionReason"])
+}
+
+func (suite *HandlerSuite) TestConfigChange() {
+	suite.policy = func(ctx context.Context, req *cosigner.Request) (cosigner.Decision, error) {
+		suite.received = req
+		return cosigner.Ignore(), nil
+	}
+
+	token := suite.request(suite.cosignerKey, jwt.MapClaims{
+		"requestId": "request-3",
+		"type":      "UNMANAGED_WALLET",
+		"extraInfo": map[string]interface{}{"walletName": "counterparty"},
+	})
+
+	status, claims := suite.send("/callback"+cosigner.ConfigChangeSignPath, token)
+	require.Equal(suite.T(), http.StatusOK, status)
+	require.Equal(suite.T(), "IGNORE", claims["action"])
+	require.Equal(suite.T(), "UNMANAGED_WALLET", suite.received.ConfigChange.Type)
+	require.JSONEq(suite.T(), `{"walletName":"counterparty"}`, string(suite.received.ConfigChange.ExtraInfo))
+}
+
+func (suite *HandlerSuite) TestRejectsTokenOfUnknownKey() {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(suite.T(), err)
+
+	token := suite.request(other, jwt.MapClaims{"requestId": "request-4"})
+
+	status, _ := suite.send(cosigner.TransactionSignPath, token)
+	require.Equal(suite.T(), http.StatusUnauthorized, status)
+	require.Nil(suite.T(), suite.received)
+
+	_, err = suite.handler.ParseRequest(cosigner.RequestTypeTransaction, token)
+	require.ErrorIs(suite.T(), err, cosigner.ErrInvalidToken)
+}
+
+func (suite *HandlerSuite) TestPolicyError() {
+	suite.policy = func(ctx context.Context, req *cosigner.Request) (cosigner.Decision, error) {
+		return cosigner.Decision{}, errors.New("policy engine is down")
+	}
+
+	token := suite.request(suite.cosignerKey, jwt.MapClaims{"requestId": "request-5"})
+
+	status, _ := suite.send(cosigner.TransactionSignPath, token)
+	require.Equal(suite.T(), http.StatusInternalServerError, status)
+}
+
+func (suite *HandlerSuite) TestRejectsTooLargeBody() {
+	handler, err := cosigner.NewHandler(&suite.cosignerKey.PublicKey, suite.callbackKey, suite.policy, cosigner.WithMaxBodySize(16))
+	require.NoError(suite.T(), err)
+
+	token := suite.request(suite.cosignerKey, jwt.MapClaims{"requestId": "request-6"})
+	req := httptest.NewRequest(http.MethodPost, cosigner.TransactionSignPath, strings.NewReader(token))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(suite.T(), http.StatusRequestEntityTooLarge, rec.Code)
+	require.Nil(suite.T(), suite.received)
+}
+
+func (suite *HandlerSuite) TestUnknownPath() {
+	status, _ := suite.send("/v2/unknown", "")
+	require.Equal(suite.T(), http.StatusNotFound, status)
+}
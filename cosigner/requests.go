@@ -0,0 +1,115 @@
+package cosigner
+
+import (
+	"encoding/json"
+)
+
+type Action string
+
+const (
+	ActionApprove Action = "APPROVE"
+	ActionReject  Action = "REJECT"
+	ActionIgnore  Action = "IGNORE"
+)
+
+type RequestType string
+
+const (
+	RequestTypeTransaction  RequestType = "TRANSACTION"
+	RequestTypeConfigChange RequestType = "CONFIG_CHANGE"
+)
+
+/*
+{
+    "txId": "string",
+    "operation": "TRANSFER",
+    "sourceType": "VAULT",
+    "sourceId": "string",
+    "destType": "VAULT | EXCHANGE | UNMANAGED",
+    "destId": "string",
+    "asset": "string",
+    "amount": "number",
+    "amountStr": "string",
+    "requestedAmount": "number",
+    "requestedAmountStr": "string",
+    "fee": "string",
+    "destAddressType": "ONE_TIME | WHITELISTED",
+    "destAddress": "string",
+    "destinations": [],
+    "requestId": "string",
+    "note": "string"
+}
+*/
+
+// TransactionSignRequest is the payload of /v2/tx_sign_request
+type TransactionSignRequest struct {
+	TxID               string                   `json:"txId"`
+	Operation          string                   `json:"operation"`
+	SourceType         string                   `json:"sourceType"`
+	SourceID           string                   `json:"sourceId"`
+	DestType           string                   `json:"destType"`
+	DestID             string                   `json:"destId"`
+	Asset              string                   `json:"asset"`
+	Amount             float64                  `json:"amount"`
+	AmountStr          string                   `json:"amountStr"`
+	RequestedAmount    float64                  `json:"requestedAmount"`
+	RequestedAmountStr string                   `json:"requestedAmountStr"`
+	Fee                string                   `json:"fee,omitempty"`
+	DestAddressType    string                   `json:"destAddressType"`
+	DestAddress        string                   `json:"destAddress"`
+	Destinations       []TransactionDestination `json:"destinations,omitempty"`
+	RequestID          string                   `json:"requestId"`
+	Note               string                   `json:"note,omitempty"`
+	Players            []string                 `json:"players,omitempty"`
+	ExtraParameters    map[string]interface{}   `json:"extraParameters,omitempty"`
+}
+
+type TransactionDestination struct {
+	AmountNative      float64 `json:"amountNative"`
+	AmountNativeStr   string  `json:"amountNativeStr"`
+	AmountUSD         float64 `json:"amountUSD"`
+	DstAddress        string  `json:"dstAddress"`
+	DstAddressType    string  `json:"dstAddressType"`
+	DstID             string  `json:"dstId"`
+	DstWalletID       string  `json:"dstWalletId,omitempty"`
+	DstName           string  `json:"dstName"`
+	DstSubType        string  `json:"dstSubType"`
+	DstType           string  `json:"dstType"`
+	DisplayDstAddress string  `json:"displayDstAddress,omitempty"`
+}
+
+// ConfigChangeSignRequest is the payload of /v2/config_change_sign_request
+type ConfigChangeSignRequest struct {
+	Type      string          `json:"type"` // UNMANAGED_WALLET, EXCHANGE, FIAT_ACCOUNT etc.
+	ExtraInfo json.RawMessage `json:"extraInfo,omitempty"`
+	RequestID string          `json:"requestId"`
+}
+
+// Request is the verified callback request, exactly one of Transaction and ConfigChange is set
+type Request struct {
+	Type         RequestType
+	RequestID    string
+	Transaction  *TransactionSignRequest
+	ConfigChange *ConfigChangeSignRequest
+}
+
+// Decision is the answer of PolicyFunc, RejectionReason is sent only with REJECT
+type Decision struct {
+	Action          Action
+	RejectionReason string
+}
+
+// Approve is shortcut for the APPROVE decision
+func Approve() Decision {
+	return Decision{Action: ActionApprove}
+}
+
+// Reject is shortcut for the REJECT decision
+func Reject(reason string) Decision {
+	return Decision{Action: ActionReject, RejectionReason: reason}
+}
+
+// Ignore is shortcut for the IGNORE decision
+func Ignore() Decision {
+	return Decision{Action: ActionIgnore}
+}
@@ -1,5 +1,26 @@
 package fireblocksdk
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors, use errors.Is to check what kind of APIError was returned
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+
+	// ErrUnexpectedStatus is matched by APIError with a successful status which the endpoint does not document
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
+
 var errorCodes = map[int]string{
 	1000: "GET_VAULT_ACCOUNTS_INVALID_PARAMS",
 	1001: "GET_VAULT_ACCOUNTS_UNEXPECTED_ERROR",
@@ -32,20 +53,78 @@ var errorCodes = map[int]string{
 	1030: "MAX_SPENDABLE_AMOUNT_UNEXPECTED_ERROR",
 }
 
-type sdkError struct {
-	Code    int    `json:"code,omitempty"`
-	Path    string `json:"path,omitempty"`
-	Message string `json:"message,omitempty"`
+// APIError is returned for every response which Fireblocks answered with error status
+type APIError struct {
+	StatusCode int    `json:"-"`                 // HTTP status of the response
+	Code       int    `json:"code,omitempty"`    // Fireblocks error code
+	TextCode   string `json:"-"`                 // Name of the Fireblocks error code, see errorCodes
+	Message    string `json:"message,omitempty"` // Fireblocks error message
+	Path       string `json:"path,omitempty"`    // Method and URL of the request
+	RequestID  string `json:"-"`                 // Value of x-request-id response header
+	Body       []byte `json:"-"`                 // Raw response body
+
+	RetryAfter time.Duration `json:"-"` // Delay requested by Retry-After header of 429 and 503 responses
+}
+
+func newAPIError(method, path string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{}
+
+	// body is not always JSON, e.g. errors produced by proxies or load balancers
+	_ = json.Unmarshal(body, apiErr)
+
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Path = fmt.Sprintf("%s %s", method, path)
+	apiErr.RequestID = resp.Header.Get("x-request-id")
+	apiErr.Body = body
+	apiErr.TextCode = errorCodes[apiErr.Code]
+	apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
 }
 
-func (e sdkError) Error() string {
-	return e.Message
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: status %d", e.Path, e.StatusCode)
+	if e.Code != 0 {
+		msg = fmt.Sprintf("%s, code %d", msg, e.Code)
+	}
+
+	msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request id: %s)", msg, e.RequestID)
+	}
+
+	return msg
 }
 
-func (e sdkError) GetTextCode() string {
+// GetTextCode returns name of the Fireblocks error code or message when code is unknown
+func (e *APIError) GetTextCode() string {
 	code, ok := errorCodes[e.Code]
 	if !ok {
 		return e.Message
 	}
 	return code
 }
+
+// Is matches APIError with sentinel errors by HTTP status and Fireblocks error code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || strings.HasSuffix(errorCodes[e.Code], "_NOT_FOUND")
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest ||
+			e.StatusCode == http.StatusUnprocessableEntity ||
+			strings.Contains(errorCodes[e.Code], "INVALID_PARAM")
+	case ErrUnexpectedStatus:
+		return e.StatusCode < http.StatusBadRequest
+	}
+
+	return false
+}
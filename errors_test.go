@@ -0,0 +1,187 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
+
+type ErrorsSuite struct {
+	suite.Suite
+	server *httptest.Server
+	sdk    *sdk.FireblocksSDK
+	status int
+	body   string
+}
+
+func (suite *ErrorsSuite) SetupTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "request-1")
+		w.WriteHeader(suite.status)
+		_, _ = w.Write([]byte(suite.body))
+	}))
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), suite.server.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+}
+
+func (suite *ErrorsSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *ErrorsSuite) TestNotFound() {
+	suite.status = http.StatusNotFound
+	suite.body = `{"code":1004,"message":"Vault account not found"}`
+
+	_, err := suite.sdk.GetVaultAccountsByID("1")
+	require.Error(suite.T(), err)
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+	require.NotErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	var apiErr *sdk.APIError
+	require.True(suite.T(), errors.As(err, &apiErr))
+	require.Equal(suite.T(), http.StatusNotFound, apiErr.StatusCode)
+	require.Equal(suite.T(), 1004, apiErr.Code)
+	require.Equal(suite.T(), "GET_VAULT_ACCOUNT_BY_ID_NOT_FOUND", apiErr.TextCode)
+	require.Equal(suite.T(), "Vault account not found", apiErr.Message)
+	require.Equal(suite.T(), "request-1", apiErr.RequestID)
+	require.Equal(suite.T(), suite.body, string(apiErr.Body))
+	require.Contains(suite.T(), apiErr.Error(), "/v1/vault/accounts/1")
+}
+
+func (suite *ErrorsSuite) TestValidationByFireblocksCode() {
+	suite.status = http.StatusConflict
+	suite.body = `{"code":1002,"message":"Invalid name"}`
+
+	_, err := suite.sdk.CreateVaultAccount("name", "", nil, nil)
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+}
+
+func (suite *ErrorsSuite) TestUnauthorized() {
+	suite.status = http.StatusUnauthorized
+	suite.body = `{"code":-7,"message":"Unauthorized: Token was not accepted"}`
+
+	_, err := suite.sdk.GetSupportedAssets()
+	require.ErrorIs(suite.T(), err, sdk.ErrUnauthorized)
+}
+
+func (suite *ErrorsSuite) TestNonJSONBody() {
+	suite.status = http.StatusNotImplemented
+	suite.body = `<html>Not Implemented</html>`
+
+	_, err := suite.sdk.GetSupportedAssets()
+	require.Error(suite.T(), err)
+
+	var apiErr *sdk.APIError
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), http.StatusNotImplemented, apiErr.StatusCode)
+	require.Equal(suite.T(), "Not Implemented", apiErr.Message)
+	require.Equal(suite.T(), suite.body, string(apiErr.Body))
+}
+
+func (suite *ErrorsSuite) TestRateLimited() {
+	err := errors.Wrap(&sdk.APIError{StatusCode: http.StatusTooManyRequests}, "failed to make request")
+	require.ErrorIs(suite.T(), err, sdk.ErrRateLimited)
+	require.NotErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *ErrorsSuite) TestUnexpectedSuccessStatus() {
+	suite.status = http.StatusAccepted
+	suite.body = `{"id":"1"}`
+
+	resp, err := suite.sdk.GetVaultAccountsByID("1")
+	require.Error(suite.T(), err)
+	require.Nil(suite.T(), resp)
+	require.ErrorIs(suite.T(), err, sdk.ErrUnexpectedStatus)
+
+	var apiErr *sdk.APIError
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), http.StatusAccepted, apiErr.StatusCode)
+}
+
+func (suite *ErrorsSuite) TestCreatedIsAcceptedForCreate() {
+	suite.status = http.StatusCreated
+	suite.body = `{"id":"5","name":"name"}`
+
+	resp, err := suite.sdk.CreateVaultAccount("name", "", nil, nil)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "5", resp.ID)
+}
+
+func (suite *ErrorsSuite) TestServerErrorFromCustomClient() {
+	client := &statusClient{status: http.StatusBadGateway, body: []byte("bad gateway")}
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), "", sdk.WithAPIClient(client))
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssets()
+	require.Error(suite.T(), err)
+
+	var apiErr *sdk.APIError
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), http.StatusBadGateway, apiErr.StatusCode)
+	require.NotErrorIs(suite.T(), err, sdk.ErrUnexpectedStatus)
+}
+
+func (suite *ErrorsSuite) TestCancelledContextWithPlainClient() {
+	client := &statusClient{status: http.StatusOK, body: []byte("[]")}
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), "", sdk.WithAPIClient(client))
+	require.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = fb.GetSupportedAssetsWithContext(ctx)
+	require.ErrorIs(suite.T(), err, context.Canceled)
+
+	assets, err := fb.GetSupportedAssets()
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), assets)
+}
+
+func (suite *ErrorsSuite) TestPatchNotSupportedByPlainClient() {
+	client := &statusClient{status: http.StatusOK, body: []byte(`{"success":true}`)}
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), "", sdk.WithAPIClient(client))
+	require.NoError(suite.T(), err)
+
+	_, err = fb.SetNetworkConnectionRoutingPolicy("c1", sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.RouteToVault("0")})
+	require.ErrorIs(suite.T(), err, sdk.ErrPatchNotSupported)
+}
+
+// statusClient answers every request with the same status and body and never returns an error,
+// it implements only IAPIClient
+type statusClient struct {
+	status int
+	body   []byte
+}
+
+func (c *statusClient) DoPostRequest(string, interface{}, ...func(*sdk.PostRequestOption)) ([]byte, int, error) {
+	return c.body, c.status, nil
+}
+
+func (c *statusClient) DoGetRequest(string, url.Values) ([]byte, int, error) {
+	return c.body, c.status, nil
+}
+
+func (c *statusClient) DoPutRequest(string, interface{}) ([]byte, int, error) {
+	return c.body, c.status, nil
+}
+
+func (c *statusClient) DoDeleteRequest(string) ([]byte, int, error) {
+	return c.body, c.status, nil
+}
@@ -0,0 +1,179 @@
+package fireblocksdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ExchangeAccount endpoint
+
+type ExchangeType string
+
+const (
+	ExchangeTypeBinance   ExchangeType = "BINANCE"
+	ExchangeTypeBinanceUS ExchangeType = "BINANCEUS"
+	ExchangeTypeBitfinex  ExchangeType = "BITFINEX"
+	ExchangeTypeBitstamp  ExchangeType = "BITSTAMP"
+	ExchangeTypeBybit     ExchangeType = "BYBIT_V2"
+	ExchangeTypeCoinbase  ExchangeType = "COINBASEPRO"
+	ExchangeTypeDeribit   ExchangeType = "DERIBIT"
+	ExchangeTypeKraken    ExchangeType = "KRAKEN"
+	ExchangeTypeOKX       ExchangeType = "OKEX"
+)
+
+// TradingAccountType is the type of the exchange sub-account, the main account has no trading type
+type TradingAccountType string
+
+const (
+	TradingAccountTypeExchange     TradingAccountType = "EXCHANGE"
+	TradingAccountTypeFunding      TradingAccountType = "FUNDING"
+	TradingAccountTypeFundable     TradingAccountType = "FUNDABLE"
+	TradingAccountTypeSpot         TradingAccountType = "SPOT"
+	TradingAccountTypeMargin       TradingAccountType = "MARGIN"
+	TradingAccountTypeMarginCross  TradingAccountType = "MARGIN_CROSS"
+	TradingAccountTypeFutures      TradingAccountType = "FUTURES"
+	TradingAccountTypeFuturesCross TradingAccountType = "FUTURES_CROSS"
+	TradingAccountTypeCoinFutures  TradingAccountType = "COIN_FUTURES"
+	TradingAccountTypeUSDTFutures  TradingAccountType = "USDT_FUTURES"
+	TradingAccountTypeCoinMargined TradingAccountType = "COIN_MARGINED_SWAP"
+	TradingAccountTypeUSDTMargined TradingAccountType = "USDT_MARGINED_SWAP_CROSS"
+	TradingAccountTypeOptions      TradingAccountType = "OPTIONS"
+	TradingAccountTypeUnified      TradingAccountType = "UNIFIED"
+)
+
+// Responses
+
+/*
+export interface ExchangeResponse {
+    id: string;
+    type: string;
+    name: string;
+    assets: AssetResponse[];
+    isSubaccount: boolean;
+    status: string;
+}
+*/
+
+// ExchangeAssetResponse has the balances of AssetResponse, Credit is the credit line of the asset
+type ExchangeAssetResponse struct {
+	AssetResponse
+	Credit string `json:"credit,omitempty"`
+}
+
+// TradingAccountResponse defines model for ExchangeTradingAccount.
+type TradingAccountResponse struct {
+	Type   TradingAccountType       `json:"type,omitempty"`
+	Name   string                   `json:"name,omitempty"`
+	Assets []*ExchangeAssetResponse `json:"assets,omitempty"`
+}
+
+// ExchangeAccountResponse defines model for ExchangeAccount.
+type ExchangeAccountResponse struct {
+	ID              string                    `json:"id,omitempty"`
+	Type            ExchangeType              `json:"type,omitempty"`
+	Name            string                    `json:"name,omitempty"`
+	Status          string                    `json:"status,omitempty"` // APPROVED | PENDING_APPROVAL | ERROR etc.
+	Assets          []*ExchangeAssetResponse  `json:"assets,omitempty"`
+	TradingAccounts []*TradingAccountResponse `json:"tradingAccounts,omitempty"`
+	IsSubaccount    bool                      `json:"isSubaccount,omitempty"`
+	MainAccountID   string                    `json:"mainAccountId,omitempty"` // [optional] ID of the main account when IsSubaccount
+}
+
+// Requests
+
+// ExchangeTransferRequest moves the asset between the trading accounts of the exchange account
+type ExchangeTransferRequest struct {
+	Asset      string             `json:"asset"`
+	Amount     string             `json:"amount"`
+	SourceType TradingAccountType `json:"sourceType"`
+	DestType   TradingAccountType `json:"destType"`
+}
+
+// ExchangeConvertRequest converts SrcAsset to DestAsset inside the exchange account, e.g. USD to USDC on Coinbase
+type ExchangeConvertRequest struct {
+	SrcAsset  string `json:"srcAsset"`
+	DestAsset string `json:"destAsset"`
+	Amount    string `json:"amount"`
+}
+
+// GetExchangeAccounts Gets all exchange accounts of the workspace
+func (sdk *FireblocksSDK) GetExchangeAccounts() (resp []*ExchangeAccountResponse, err error) {
+	return sdk.GetExchangeAccountsWithContext(context.Background())
+}
+
+// GetExchangeAccountsWithContext is GetExchangeAccounts with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetExchangeAccountsWithContext(ctx context.Context) (resp []*ExchangeAccountResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/exchange_accounts", nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetExchangeAccountByID Gets the exchange account with balances of its trading accounts
+func (sdk *FireblocksSDK) GetExchangeAccountByID(exchangeAccountID string) (resp *ExchangeAccountResponse, err error) {
+	return sdk.GetExchangeAccountByIDWithContext(context.Background(), exchangeAccountID)
+}
+
+// GetExchangeAccountByIDWithContext is GetExchangeAccountByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetExchangeAccountByIDWithContext(ctx context.Context, exchangeAccountID string) (resp *ExchangeAccountResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/exchange_accounts/%s", url.PathEscape(exchangeAccountID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetExchangeAccountAsset Gets the balance of the asset in the main exchange account
+func (sdk *FireblocksSDK) GetExchangeAccountAsset(exchangeAccountID, assetID string) (resp *ExchangeAssetResponse, err error) {
+	return sdk.GetExchangeAccountAssetWithContext(context.Background(), exchangeAccountID, assetID)
+}
+
+// GetExchangeAccountAssetWithContext is GetExchangeAccountAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetExchangeAccountAssetWithContext(ctx context.Context, exchangeAccountID, assetID string) (resp *ExchangeAssetResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(
+		ctx,
+		fmt.Sprintf("/exchange_accounts/%s/%s", url.PathEscape(exchangeAccountID), url.PathEscape(assetID)),
+		nil,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// TransferFromExchangeAccount Transfers the asset between the main and trading accounts of the exchange account,
+// the funds do not leave the exchange so no transaction is created
+func (sdk *FireblocksSDK) TransferFromExchangeAccount(exchangeAccountID string, req *ExchangeTransferRequest, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.TransferFromExchangeAccountWithContext(context.Background(), exchangeAccountID, req, opts...)
+}
+
+// TransferFromExchangeAccountWithContext is TransferFromExchangeAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) TransferFromExchangeAccountWithContext(ctx context.Context, exchangeAccountID string, req *ExchangeTransferRequest, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/exchange_accounts/%s/internal_transfer", url.PathEscape(exchangeAccountID)),
+		req,
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// ConvertExchangeAsset Converts the asset inside the exchange account, supported by exchanges with conversions
+func (sdk *FireblocksSDK) ConvertExchangeAsset(exchangeAccountID string, req *ExchangeConvertRequest, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.ConvertExchangeAssetWithContext(context.Background(), exchangeAccountID, req, opts...)
+}
+
+// ConvertExchangeAssetWithContext is ConvertExchangeAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) ConvertExchangeAssetWithContext(ctx context.Context, exchangeAccountID string, req *ExchangeConvertRequest, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/exchange_accounts/%s/convert", url.PathEscape(exchangeAccountID)),
+		req,
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
@@ -0,0 +1,140 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestExchangeSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeSuite))
+}
+
+type ExchangeSuite struct {
+	suite.Suite
+	srv        *fireblockstest.Server
+	sdk        *sdk.FireblocksSDK
+	exchangeID string
+}
+
+func (suite *ExchangeSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+	suite.exchangeID = suite.srv.AddExchangeAccount(sdk.ExchangeAccountResponse{
+		Type: sdk.ExchangeTypeCoinbase,
+		Name: "coinbase",
+		Assets: []*sdk.ExchangeAssetResponse{
+			{AssetResponse: sdk.AssetResponse{ID: "USD", Total: "100", Available: "100"}},
+		},
+		TradingAccounts: []*sdk.TradingAccountResponse{
+			{Type: sdk.TradingAccountTypeSpot, Assets: []*sdk.ExchangeAssetResponse{{AssetResponse: sdk.AssetResponse{ID: "BTC", Total: "1.5", Available: "1.5"}}}},
+			{Type: sdk.TradingAccountTypeFutures},
+		},
+	})
+}
+
+func (suite *ExchangeSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *ExchangeSuite) TestGetExchangeAccounts() {
+	accounts, err := suite.sdk.GetExchangeAccounts()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), accounts, 1)
+	require.Equal(suite.T(), sdk.ExchangeTypeCoinbase, accounts[0].Type)
+	require.Equal(suite.T(), "APPROVED", accounts[0].Status)
+
+	account, err := suite.sdk.GetExchangeAccountByID(suite.exchangeID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "coinbase", account.Name)
+	require.Len(suite.T(), account.TradingAccounts, 2)
+	require.Equal(suite.T(), "1.5", account.TradingAccounts[0].Assets[0].Total)
+
+	asset, err := suite.sdk.GetExchangeAccountAsset(suite.exchangeID, "USD")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "100", asset.Available)
+
+	_, err = suite.sdk.GetExchangeAccountByID("unknown")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+
+	_, err = suite.sdk.GetExchangeAccountAsset(suite.exchangeID, "ETH")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *ExchangeSuite) TestTransferFromExchangeAccount() {
+	resp, err := suite.sdk.TransferFromExchangeAccount(suite.exchangeID, &sdk.ExchangeTransferRequest{
+		Asset:      "BTC",
+		Amount:     "0.5",
+		SourceType: sdk.TradingAccountTypeSpot,
+		DestType:   sdk.TradingAccountTypeFutures,
+	})
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+
+	account, err := suite.sdk.GetExchangeAccountByID(suite.exchangeID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "1", account.TradingAccounts[0].Assets[0].Available)
+	require.Equal(suite.T(), "0.5", account.TradingAccounts[1].Assets[0].Available)
+
+	_, err = suite.sdk.TransferFromExchangeAccount(suite.exchangeID, &sdk.ExchangeTransferRequest{
+		Asset:      "BTC",
+		Amount:     "2",
+		SourceType: sdk.TradingAccountTypeSpot,
+		DestType:   sdk.TradingAccountTypeFutures,
+	})
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	_, err = suite.sdk.TransferFromExchangeAccount(suite.exchangeID, &sdk.ExchangeTransferRequest{
+		Asset:      "BTC",
+		Amount:     "0.1",
+		SourceType: sdk.TradingAccountTypeSpot,
+		DestType:   sdk.TradingAccountTypeMargin,
+	})
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+}
+
+func (suite *ExchangeSuite) TestTransferIsIdempotent() {
+	req := &sdk.ExchangeTransferRequest{
+		Asset:      "BTC",
+		Amount:     "0.5",
+		SourceType: sdk.TradingAccountTypeSpot,
+		DestType:   sdk.TradingAccountTypeFutures,
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.sdk.TransferFromExchangeAccount(suite.exchangeID, req, sdk.WithIdempotencyKey("transfer-1"))
+		require.NoError(suite.T(), err)
+	}
+
+	account, ok := suite.srv.ExchangeAccount(suite.exchangeID)
+	require.True(suite.T(), ok)
+	require.Equal(suite.T(), "1", account.TradingAccounts[0].Assets[0].Available)
+}
+
+func (suite *ExchangeSuite) TestConvertExchangeAsset() {
+	resp, err := suite.sdk.ConvertExchangeAsset(suite.exchangeID, &sdk.ExchangeConvertRequest{
+		SrcAsset:  "USD",
+		DestAsset: "USDC",
+		Amount:    "40.25",
+	})
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+
+	usd, err := suite.sdk.GetExchangeAccountAsset(suite.exchangeID, "USD")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "59.75", usd.Total)
+
+	usdc, err := suite.sdk.GetExchangeAccountAsset(suite.exchangeID, "USDC")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "40.25", usdc.Total)
+
+	_, err = suite.sdk.ConvertExchangeAsset(suite.exchangeID, &sdk.ExchangeConvertRequest{SrcAsset: "USD", DestAsset: "USDC", Amount: "-1"})
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+}
@@ -0,0 +1,194 @@
+package fireblocksdk
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Fee estimation endpoints
+
+// ErrFeeCapExceeded is returned by FeePolicy when no allowed fee level is within MaxFee
+var ErrFeeCapExceeded = errors.New("fee cap exceeded")
+
+// feeLevels are ordered from the cheapest to the fastest
+var feeLevels = []FeeLevel{FeeLevelLow, FeeLevelMedium, FeeLevelHigh}
+
+// Responses
+
+/*
+export interface EstimateFeeResponse {
+    low: EstimatedFee;
+    medium: EstimatedFee;
+    high: EstimatedFee;
+}
+*/
+
+// EstimatedFee defines model for the fee of one fee level, which fields are set depends on the asset
+type EstimatedFee struct {
+	NetworkFee  string `json:"networkFee,omitempty"`  // The total fee in native units of the fee asset, e.g. ETH
+	GasPrice    string `json:"gasPrice,omitempty"`    // [optional] Gas price in gwei, for Ethereum and EVM chains
+	GasLimit    string `json:"gasLimit,omitempty"`    // [optional] Only for transaction estimates
+	FeePerByte  string `json:"feePerByte,omitempty"`  // [optional] For UTXO based assets
+	BaseFee     string `json:"baseFee,omitempty"`     // [optional] EIP-1559 base fee in gwei
+	PriorityFee string `json:"priorityFee,omitempty"` // [optional] EIP-1559 priority fee in gwei
+}
+
+// EstimateFeeResponse defines model for the fee estimates of all fee levels.
+type EstimateFeeResponse struct {
+	Low    *EstimatedFee `json:"low,omitempty"`
+	Medium *EstimatedFee `json:"medium,omitempty"`
+	High   *EstimatedFee `json:"high,omitempty"`
+}
+
+// Fee returns the estimate of the fee level, nil for unknown levels
+func (r *EstimateFeeResponse) Fee(level FeeLevel) *EstimatedFee {
+	switch level {
+	case FeeLevelLow:
+		return r.Low
+	case FeeLevelMedium:
+		return r.Medium
+	case FeeLevelHigh:
+		return r.High
+	default:
+		return nil
+	}
+}
+
+// FeePolicy picks the fee level of the transaction from its estimate
+type FeePolicy struct {
+	Level    FeeLevel // Preferred fee level, MEDIUM when empty
+	MaxFee   string   // Cap of the network fee in native units of the fee asset, no cap when empty
+	Fallback bool     // Use cheaper levels when the preferred one is over MaxFee
+	// CapUnitFee sets MaxFee of the transaction to the gas price or fee per byte of the selected level,
+	// so Fireblocks does not pay more per unit when the fee rises before the transaction is signed
+	CapUnitFee bool
+}
+
+// Select returns the preferred level, or with Fallback the fastest cheaper level, whose network fee is within MaxFee
+func (p *FeePolicy) Select(estimate *EstimateFeeResponse) (FeeLevel, *EstimatedFee, error) {
+	if estimate == nil {
+		return "", nil, errors.New("fee estimate is empty")
+	}
+
+	level := p.Level
+	if level == "" {
+		level = FeeLevelMedium
+	}
+
+	preferred := -1
+	for i, l := range feeLevels {
+		if l == level {
+			preferred = i
+		}
+	}
+
+	if preferred < 0 {
+		return "", nil, errors.Errorf("unknown fee level %q", level)
+	}
+
+	var maxFee *big.Rat
+	if p.MaxFee != "" {
+		var ok bool
+		if maxFee, ok = new(big.Rat).SetString(p.MaxFee); !ok || maxFee.Sign() < 0 {
+			return "", nil, errors.Errorf("invalid max fee %q", p.MaxFee)
+		}
+	}
+
+	lowest := preferred
+	if p.Fallback {
+		lowest = 0
+	}
+
+	for i := preferred; i >= lowest; i-- {
+		fee := estimate.Fee(feeLevels[i])
+		if fee == nil {
+			return "", nil, errors.Errorf("no estimate of %s fee", feeLevels[i])
+		}
+
+		if maxFee == nil {
+			return feeLevels[i], fee, nil
+		}
+
+		networkFee, ok := new(big.Rat).SetString(fee.NetworkFee)
+		if !ok {
+			return "", nil, errors.Errorf("invalid network fee %q of %s fee", fee.NetworkFee, feeLevels[i])
+		}
+
+		if networkFee.Cmp(maxFee) <= 0 {
+			return feeLevels[i], fee, nil
+		}
+	}
+
+	return "", nil, errors.Wrapf(ErrFeeCapExceeded, "%s fee is %s, the cap is %s", feeLevels[lowest], estimate.Fee(feeLevels[lowest]).NetworkFee, p.MaxFee)
+}
+
+// EstimateTransactionFee Estimates the fee of the transaction for all fee levels, the transaction is not created
+func (sdk *FireblocksSDK) EstimateTransactionFee(tx *TransactionRequest, opts ...func(*PostRequestOption)) (resp *EstimateFeeResponse, err error) {
+	return sdk.EstimateTransactionFeeWithContext(context.Background(), tx, opts...)
+}
+
+// EstimateTransactionFeeWithContext is EstimateTransactionFee with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) EstimateTransactionFeeWithContext(ctx context.Context, tx *TransactionRequest, opts ...func(*PostRequestOption)) (resp *EstimateFeeResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, "/transactions/estimate_fee", tx, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// GetNetworkFee Gets the current network fee of the asset for all fee levels
+func (sdk *FireblocksSDK) GetNetworkFee(assetID string) (resp *EstimateFeeResponse, err error) {
+	return sdk.GetNetworkFeeWithContext(context.Background(), assetID)
+}
+
+// GetNetworkFeeWithContext is GetNetworkFee with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetNetworkFeeWithContext(ctx context.Context, assetID string) (resp *EstimateFeeResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/estimate_network_fee", url.Values{"assetId": {assetID}})
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// ApplyFeePolicy Estimates the fee of the transaction and sets its FeeLevel chosen by the policy,
+// tx is not changed when the policy refuses all levels. The fee may still change before the transaction is created,
+// with CapUnitFee of the policy Fireblocks caps the per unit fee at the estimate of the selected level.
+func (sdk *FireblocksSDK) ApplyFeePolicy(tx *TransactionRequest, policy *FeePolicy) (*EstimatedFee, error) {
+	return sdk.ApplyFeePolicyWithContext(context.Background(), tx, policy)
+}
+
+// ApplyFeePolicyWithContext is ApplyFeePolicy with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) ApplyFeePolicyWithContext(ctx context.Context, tx *TransactionRequest, policy *FeePolicy) (*EstimatedFee, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is nil")
+	}
+
+	if policy == nil {
+		return nil, errors.New("fee policy is nil")
+	}
+
+	estimate, err := sdk.EstimateTransactionFeeWithContext(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	level, fee, err := policy.Select(estimate)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.FeeLevel = level
+	if policy.CapUnitFee {
+		// maxFee of the transaction is per unit, gas price in gwei or fee per byte, not the total fee
+		switch {
+		case fee.GasPrice != "":
+			tx.MaxFee = fee.GasPrice
+		case fee.FeePerByte != "":
+			tx.MaxFee = fee.FeePerByte
+		}
+	}
+
+	return fee, nil
+}
@@ -0,0 +1,135 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var ethEstimate = sdk.EstimateFeeResponse{
+	Low:    &sdk.EstimatedFee{NetworkFee: "0.00042", GasPrice: "20", GasLimit: "21000", BaseFee: "18", PriorityFee: "2"},
+	Medium: &sdk.EstimatedFee{NetworkFee: "0.00063", GasPrice: "30", GasLimit: "21000", BaseFee: "18", PriorityFee: "12"},
+	High:   &sdk.EstimatedFee{NetworkFee: "0.00105", GasPrice: "50", GasLimit: "21000", BaseFee: "18", PriorityFee: "32"},
+}
+
+func TestFeePolicySelect(t *testing.T) {
+	level, fee, err := (&sdk.FeePolicy{}).Select(&ethEstimate)
+	require.NoError(t, err)
+	require.Equal(t, sdk.FeeLevelMedium, level)
+	require.Equal(t, "30", fee.GasPrice)
+
+	level, _, err = (&sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.00105"}).Select(&ethEstimate)
+	require.NoError(t, err)
+	require.Equal(t, sdk.FeeLevelHigh, level)
+
+	_, _, err = (&sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.001"}).Select(&ethEstimate)
+	require.ErrorIs(t, err, sdk.ErrFeeCapExceeded)
+
+	level, fee, err = (&sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.001", Fallback: true}).Select(&ethEstimate)
+	require.NoError(t, err)
+	require.Equal(t, sdk.FeeLevelMedium, level)
+	require.Equal(t, "0.00063", fee.NetworkFee)
+
+	_, _, err = (&sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.0001", Fallback: true}).Select(&ethEstimate)
+	require.ErrorIs(t, err, sdk.ErrFeeCapExceeded)
+
+	_, _, err = (&sdk.FeePolicy{Level: "FASTEST"}).Select(&ethEstimate)
+	require.Error(t, err)
+
+	_, _, err = (&sdk.FeePolicy{MaxFee: "cheap"}).Select(&ethEstimate)
+	require.Error(t, err)
+
+	_, _, err = (&sdk.FeePolicy{}).Select(&sdk.EstimateFeeResponse{Low: ethEstimate.Low})
+	require.Error(t, err)
+
+	_, _, err = (&sdk.FeePolicy{}).Select(nil)
+	require.Error(t, err)
+}
+
+func TestFeeSuite(t *testing.T) {
+	suite.Run(t, new(FeeSuite))
+}
+
+type FeeSuite struct {
+	suite.Suite
+	srv       *fireblockstest.Server
+	sdk       *sdk.FireblocksSDK
+	accountID string
+}
+
+func (suite *FeeSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+	suite.srv.SetFeeEstimate("ETH_TEST", ethEstimate)
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+	suite.accountID = suite.srv.AddVaultAccount("vault")
+}
+
+func (suite *FeeSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *FeeSuite) transaction() *sdk.TransactionRequest {
+	return &sdk.TransactionRequest{
+		AssetID:     "ETH_TEST",
+		Source:      sdk.VaultAccountPeer(suite.accountID),
+		Destination: sdk.OneTimeAddressPeer("0xdest", ""),
+		Amount:      "1",
+	}
+}
+
+func (suite *FeeSuite) TestEstimateTransactionFee() {
+	estimate, err := suite.sdk.EstimateTransactionFee(suite.transaction())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), &ethEstimate, estimate)
+
+	tx := suite.transaction()
+	tx.AssetID = "BTC_TEST"
+
+	_, err = suite.sdk.EstimateTransactionFee(tx)
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+}
+
+func (suite *FeeSuite) TestGetNetworkFee() {
+	estimate, err := suite.sdk.GetNetworkFee("ETH_TEST")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "0.00105", estimate.High.NetworkFee)
+
+	requests := suite.srv.Requests()
+	require.Equal(suite.T(), "assetId=ETH_TEST", requests[len(requests)-1].URL.RawQuery)
+}
+
+func (suite *FeeSuite) TestApplyFeePolicy() {
+	tx := suite.transaction()
+
+	fee, err := suite.sdk.ApplyFeePolicy(tx, &sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.0007", Fallback: true})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.FeeLevelMedium, tx.FeeLevel)
+	require.Empty(suite.T(), tx.MaxFee, "MaxFee of the policy is the total fee, not the per unit cap")
+	require.Equal(suite.T(), "0.00063", fee.NetworkFee)
+
+	tx = suite.transaction()
+
+	_, err = suite.sdk.ApplyFeePolicy(tx, &sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.0007", Fallback: true, CapUnitFee: true})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "30", tx.MaxFee, "gas price of the selected level")
+
+	tx = suite.transaction()
+
+	_, err = suite.sdk.ApplyFeePolicy(tx, &sdk.FeePolicy{Level: sdk.FeeLevelHigh, MaxFee: "0.0007"})
+	require.ErrorIs(suite.T(), err, sdk.ErrFeeCapExceeded)
+	require.Empty(suite.T(), tx.FeeLevel)
+	require.Empty(suite.T(), tx.MaxFee)
+
+	_, err = suite.sdk.ApplyFeePolicy(tx, nil)
+	require.Error(suite.T(), err)
+
+	_, err = suite.sdk.ApplyFeePolicy(nil, &sdk.FeePolicy{})
+	require.Error(suite.T(), err)
+}
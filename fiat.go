@@ -0,0 +1,111 @@
+package fireblocksdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// FiatAccount endpoint
+
+type FiatAccountType string
+
+const (
+	FiatAccountTypeBlinc FiatAccountType = "BLINC" // BLINC by BCB Group
+)
+
+// Responses
+
+/*
+export interface FiatAccountResponse {
+    id: string;
+    type: string;
+    name: string;
+    address?: string;
+    assets: FiatAsset[];
+}
+*/
+
+// FiatAssetResponse defines model for FiatAsset.
+type FiatAssetResponse struct {
+	ID      string `json:"id,omitempty"`
+	Balance string `json:"balance,omitempty"`
+}
+
+// FiatAccountResponse defines model for FiatAccount.
+type FiatAccountResponse struct {
+	ID      string               `json:"id,omitempty"`
+	Type    FiatAccountType      `json:"type,omitempty"`
+	Name    string               `json:"name,omitempty"`
+	Address string               `json:"address,omitempty"` // [optional] Address of the bank account
+	Assets  []*FiatAssetResponse `json:"assets,omitempty"`
+}
+
+// Requests
+
+type FiatTransferRequest struct {
+	Amount string `json:"amount"`
+}
+
+// GetFiatAccounts Gets all fiat accounts of the workspace
+func (sdk *FireblocksSDK) GetFiatAccounts() (resp []*FiatAccountResponse, err error) {
+	return sdk.GetFiatAccountsWithContext(context.Background())
+}
+
+// GetFiatAccountsWithContext is GetFiatAccounts with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetFiatAccountsWithContext(ctx context.Context) (resp []*FiatAccountResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/fiat_accounts", nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetFiatAccountByID Gets the fiat account with its balances
+func (sdk *FireblocksSDK) GetFiatAccountByID(fiatAccountID string) (resp *FiatAccountResponse, err error) {
+	return sdk.GetFiatAccountByIDWithContext(context.Background(), fiatAccountID)
+}
+
+// GetFiatAccountByIDWithContext is GetFiatAccountByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetFiatAccountByIDWithContext(ctx context.Context, fiatAccountID string) (resp *FiatAccountResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/fiat_accounts/%s", url.PathEscape(fiatAccountID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// RedeemToLinkedDDA Redeems amount from the fiat account to the linked Demand Deposit Account of the bank
+func (sdk *FireblocksSDK) RedeemToLinkedDDA(fiatAccountID, amount string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.RedeemToLinkedDDAWithContext(context.Background(), fiatAccountID, amount, opts...)
+}
+
+// RedeemToLinkedDDAWithContext is RedeemToLinkedDDA with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) RedeemToLinkedDDAWithContext(ctx context.Context, fiatAccountID, amount string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/fiat_accounts/%s/redeem_to_linked_dda", url.PathEscape(fiatAccountID)),
+		&FiatTransferRequest{Amount: amount},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// DepositFromLinkedDDA Deposits amount from the linked Demand Deposit Account of the bank to the fiat account
+func (sdk *FireblocksSDK) DepositFromLinkedDDA(fiatAccountID, amount string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.DepositFromLinkedDDAWithContext(context.Background(), fiatAccountID, amount, opts...)
+}
+
+// DepositFromLinkedDDAWithContext is DepositFromLinkedDDA with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DepositFromLinkedDDAWithContext(ctx context.Context, fiatAccountID, amount string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/fiat_accounts/%s/deposit_from_linked_dda", url.PathEscape(fiatAccountID)),
+		&FiatTransferRequest{Amount: amount},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
@@ -0,0 +1,97 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFiatSuite(t *testing.T) {
+	suite.Run(t, new(FiatSuite))
+}
+
+type FiatSuite struct {
+	suite.Suite
+	srv       *fireblockstest.Server
+	sdk       *sdk.FireblocksSDK
+	accountID string
+}
+
+func (suite *FiatSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+	suite.accountID = suite.srv.AddFiatAccount(sdk.FiatAccountResponse{
+		Name:   "treasury",
+		Assets: []*sdk.FiatAssetResponse{{ID: "USD", Balance: "1000"}},
+	})
+}
+
+func (suite *FiatSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *FiatSuite) balance() string {
+	account, err := suite.sdk.GetFiatAccountByID(suite.accountID)
+	require.NoError(suite.T(), err)
+
+	return account.Assets[0].Balance
+}
+
+func (suite *FiatSuite) TestGetFiatAccounts() {
+	accounts, err := suite.sdk.GetFiatAccounts()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), accounts, 1)
+	require.Equal(suite.T(), sdk.FiatAccountTypeBlinc, accounts[0].Type)
+	require.Equal(suite.T(), "treasury", accounts[0].Name)
+
+	account, err := suite.sdk.GetFiatAccountByID(suite.accountID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []*sdk.FiatAssetResponse{{ID: "USD", Balance: "1000"}}, account.Assets)
+
+	_, err = suite.sdk.GetFiatAccountByID("unknown")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *FiatSuite) TestRedeemToLinkedDDA() {
+	resp, err := suite.sdk.RedeemToLinkedDDA(suite.accountID, "250.5")
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+	require.Equal(suite.T(), "749.5", suite.balance())
+
+	_, err = suite.sdk.RedeemToLinkedDDA(suite.accountID, "10000")
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	_, err = suite.sdk.RedeemToLinkedDDA("unknown", "1")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *FiatSuite) TestDepositFromLinkedDDA() {
+	resp, err := suite.sdk.DepositFromLinkedDDA(suite.accountID, "0.75")
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+	require.Equal(suite.T(), "1000.75", suite.balance())
+
+	_, err = suite.sdk.DepositFromLinkedDDA(suite.accountID, "0")
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+}
+
+func (suite *FiatSuite) TestIdempotencyKey() {
+	first, err := suite.sdk.RedeemToLinkedDDA(suite.accountID, "100", sdk.WithIdempotencyKey("redeem-1"))
+	require.NoError(suite.T(), err)
+
+	second, err := suite.sdk.RedeemToLinkedDDA(suite.accountID, "100", sdk.WithIdempotencyKey("redeem-1"))
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), first, second)
+	require.Equal(suite.T(), "900", suite.balance())
+
+	for _, r := range suite.srv.Requests()[:2] {
+		require.Equal(suite.T(), "redeem-1", r.Header.Get("Idempotency-Key"))
+	}
+}
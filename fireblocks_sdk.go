@@ -1,11 +1,17 @@
 package fireblocksdk
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/go-retryablehttp"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type PostRequestOption struct {
@@ -30,14 +36,21 @@ func WithPaged(paged bool) func(*GetRequestOption) {
 
 type SDKOptions struct {
 	HTTPTimeoutMilliseconds time.Duration
+	httpTimeout             time.Duration
 	tokenExpirySeconds      int64
 	auth                    IAuthProvider
 	client                  IAPIClient
+	clientConfigs           []func(*APIClientConfig)
+	instrumentation         []func(*InstrumentationConfig)
 }
 
+// ErrClientOptionsWithAPIClient is returned by CreateSDK when options of the API client, like timeout,
+// retries or rate limits, are given together with WithAPIClient, they must be set on that client instead
+var ErrClientOptionsWithAPIClient = errors.New("options of the API client can't be used with WithAPIClient")
+
 type FireblocksSDK struct {
 	baseURL string
-	client  IAPIClient
+	client  contextClient
 	auth    IAuthProvider
 }
 
@@ -53,12 +66,119 @@ func WithAPIClient(client IAPIClient) func(o *SDKOptions) {
 	}
 }
 
+// WithHTTPTimout limits every attempt of the request to timeout milliseconds, e.g. 10000
+//
+// Deprecated: use WithHTTPTimeout, which takes the duration
 func WithHTTPTimout(timeout time.Duration) func(o *SDKOptions) {
 	return func(o *SDKOptions) {
 		o.HTTPTimeoutMilliseconds = timeout
 	}
 }
 
+// WithHTTPTimeout limits every attempt of the request, e.g. 10*time.Second
+func WithHTTPTimeout(timeout time.Duration) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.httpTimeout = timeout
+	}
+}
+
+// WithHTTPClient sets the base http.Client, other options are applied to its copy
+func WithHTTPClient(client *http.Client) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.HTTPClient = client
+		})
+	}
+}
+
+// WithTransport sets the transport used for requests, e.g. with proxy, client certificate or custom CA
+func WithTransport(transport http.RoundTripper) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.Transport = transport
+		})
+	}
+}
+
+// WithConnectionPool configures the pool of *http.Transport, other transports are left as is
+func WithConnectionPool(pool ConnectionPool) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.Pool = pool
+		})
+	}
+}
+
+// WithLogger sets the logger of the SDK and retryablehttp, slog.Default is used otherwise and nil disables logging
+func WithLogger(logger *slog.Logger) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.Logger = logger
+		})
+	}
+}
+
+// WithRetryMax sets the number of retries after the first attempt, 0 disables retries
+func WithRetryMax(retryMax int) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.RetryMax = retryMax
+		})
+	}
+}
+
+// WithRetryWait bounds the wait between attempts
+func WithRetryWait(min, max time.Duration) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.RetryWaitMin = min
+			c.RetryWaitMax = max
+		})
+	}
+}
+
+// WithRateLimit throttles requests of the endpoint class to rate per second with the given burst
+func WithRateLimit(class EndpointClass, rate float64, burst int) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.RateLimits[class] = RateLimit{Rate: rate, Burst: burst}
+		})
+	}
+}
+
+// WithEndpointClassifier replaces DefaultEndpointClassifier used to pick the rate limit of the request
+func WithEndpointClassifier(classify EndpointClassifier) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.Classifier = classify
+		})
+	}
+}
+
+// WithRetryPolicy replaces RetryPolicy and RetryAfterBackoff, nil keeps the default
+func WithRetryPolicy(checkRetry retryablehttp.CheckRetry, backoff retryablehttp.Backoff) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.clientConfigs = append(o.clientConfigs, func(c *APIClientConfig) {
+			c.CheckRetry = checkRetry
+			c.Backoff = backoff
+		})
+	}
+}
+
+// WithTracerProvider wraps the client, including the one set by WithAPIClient, into InstrumentedClient
+func WithTracerProvider(provider trace.TracerProvider) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.instrumentation = append(o.instrumentation, WithInstrumentationTracerProvider(provider))
+	}
+}
+
+// WithMeterProvider wraps the client, including the one set by WithAPIClient, into InstrumentedClient
+func WithMeterProvider(provider metric.MeterProvider) func(o *SDKOptions) {
+	return func(o *SDKOptions) {
+		o.instrumentation = append(o.instrumentation, WithInstrumentationMeterProvider(provider))
+	}
+}
+
 func WithTokenTimeout(exp int64) func(o *SDKOptions) {
 	return func(o *SDKOptions) {
 		o.tokenExpirySeconds = exp
@@ -81,26 +201,80 @@ func CreateSDK(apikey string, privateKey []byte, baseURL string, opts ...func(o
 		opt.auth = provider
 	}
 
+	timeout := opt.httpTimeout
+	if timeout == 0 {
+		timeout = opt.HTTPTimeoutMilliseconds * time.Millisecond
+	}
+
+	if opt.client != nil && (timeout > 0 || len(opt.clientConfigs) > 0) {
+		return nil, ErrClientOptionsWithAPIClient
+	}
+
 	if opt.client == nil {
-		opt.client = NewAPIClient(opt.auth, baseURL)
+		if timeout > 0 {
+			opt.clientConfigs = append(opt.clientConfigs, func(c *APIClientConfig) {
+				c.Timeout = timeout
+			})
+		}
+
+		opt.client = NewAPIClient(opt.auth, baseURL, opt.clientConfigs...)
+	}
+
+	if len(opt.instrumentation) > 0 {
+		instrumented, err := NewInstrumentedClient(opt.client, opt.instrumentation...)
+		if err != nil {
+			return nil, err
+		}
+
+		opt.client = instrumented
 	}
 
 	sdk := &FireblocksSDK{
 		baseURL: baseURL,
-		client:  opt.client,
+		client:  newContextClient(opt.client),
 		auth:    opt.auth,
 	}
 
 	return sdk, nil
 }
 
+// handleResponse decodes body into resp when status is one of the accepted codes of the endpoint,
+// any other status, even the successful one, becomes APIError
+func handleResponse(body []byte, status int, err error, resp interface{}, accepted ...int) error {
+	if err != nil {
+		return errors.Wrap(err, "failed to make request")
+	}
+
+	for _, code := range accepted {
+		if status != code {
+			continue
+		}
+
+		if len(body) == 0 {
+			return nil
+		}
+
+		return errors.Wrap(json.Unmarshal(body, resp), "failed to decode response")
+	}
+
+	apiErr := &APIError{
+		StatusCode: status,
+		Message:    fmt.Sprintf("unexpected status %d %s", status, http.StatusText(status)),
+		Body:       body,
+	}
+
+	return errors.Wrap(apiErr, "failed to make request")
+}
+
 // GetSupportedAssets Gets all assets that are currently supported by Fireblocks
 func (sdk *FireblocksSDK) GetSupportedAssets() (resp []*AssetTypeResponse, err error) {
-	body, status, err := sdk.client.DoGetRequest("/supported_assets", nil)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	return sdk.GetSupportedAssetsWithContext(context.Background())
+}
+
+// GetSupportedAssetsWithContext is GetSupportedAssets with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetSupportedAssetsWithContext(ctx context.Context) (resp []*AssetTypeResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/supported_assets", nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
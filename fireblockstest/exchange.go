@@ -0,0 +1,231 @@
+package fireblockstest
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	sdk "fireblocksdk"
+)
+
+// AddExchangeAccount adds the exchange account and returns its ID, a new ID is generated when account.ID is empty.
+// Assets of the account are balances of the main account, trading accounts keep their own balances.
+func (s *Server) AddExchangeAccount(account sdk.ExchangeAccountResponse) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account.ID == "" {
+		account.ID = newID()
+	}
+
+	if account.Status == "" {
+		account.Status = "APPROVED"
+	}
+
+	s.exchanges = append(s.exchanges, &account)
+
+	return account.ID
+}
+
+// ExchangeAccount returns copy of the stored exchange account
+func (s *Server) ExchangeAccount(exchangeAccountID string) (sdk.ExchangeAccountResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.findExchangeAccount(exchangeAccountID)
+	if account == nil {
+		return sdk.ExchangeAccountResponse{}, false
+	}
+
+	return *account, true
+}
+
+func (s *Server) findExchangeAccount(exchangeAccountID string) *sdk.ExchangeAccountResponse {
+	for _, account := range s.exchanges {
+		if account.ID == exchangeAccountID {
+			return account
+		}
+	}
+
+	return nil
+}
+
+func findExchangeAsset(assets []*sdk.ExchangeAssetResponse, assetID string) *sdk.ExchangeAssetResponse {
+	for _, asset := range assets {
+		if asset.ID == assetID {
+			return asset
+		}
+	}
+
+	return nil
+}
+
+func findTradingAccount(account *sdk.ExchangeAccountResponse, accountType sdk.TradingAccountType) *sdk.TradingAccountResponse {
+	for _, trading := range account.TradingAccounts {
+		if trading.Type == accountType {
+			return trading
+		}
+	}
+
+	return nil
+}
+
+// formatAmount prints the amount without trailing zeros, the way Fireblocks returns balances
+func formatAmount(amount *big.Rat) string {
+	formatted := strings.TrimRight(amount.FloatString(18), "0")
+
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// moveBalance takes amount from the source asset and adds it to the destination asset, which is created when missing
+func moveBalance(from *sdk.ExchangeAssetResponse, to *[]*sdk.ExchangeAssetResponse, toAssetID string, amount *big.Rat) error {
+	available, ok := new(big.Rat).SetString(from.Available)
+	if !ok || available.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient balance of %s", from.ID)
+	}
+
+	total, _ := new(big.Rat).SetString(from.Total)
+	if total == nil {
+		total = new(big.Rat).Set(available)
+	}
+
+	from.Available = formatAmount(available.Sub(available, amount))
+	from.Total = formatAmount(total.Sub(total, amount))
+
+	dest := findExchangeAsset(*to, toAssetID)
+	if dest == nil {
+		dest = &sdk.ExchangeAssetResponse{AssetResponse: sdk.AssetResponse{ID: toAssetID, Total: "0", Available: "0"}}
+		*to = append(*to, dest)
+	}
+
+	destAvailable, _ := new(big.Rat).SetString(dest.Available)
+	if destAvailable == nil {
+		destAvailable = new(big.Rat)
+	}
+
+	destTotal, _ := new(big.Rat).SetString(dest.Total)
+	if destTotal == nil {
+		destTotal = new(big.Rat)
+	}
+
+	dest.Available = formatAmount(destAvailable.Add(destAvailable, amount))
+	dest.Total = formatAmount(destTotal.Add(destTotal, amount))
+
+	return nil
+}
+
+func parsePositiveAmount(w http.ResponseWriter, value string) (*big.Rat, bool) {
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok || amount.Sign() <= 0 {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("invalid amount %q", value))
+		return nil, false
+	}
+
+	return amount, true
+}
+
+func (s *Server) getExchangeAccounts(w http.ResponseWriter) {
+	accounts := s.exchanges
+	if accounts == nil {
+		accounts = []*sdk.ExchangeAccountResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+func (s *Server) getExchangeAccount(w http.ResponseWriter, exchangeAccountID string) {
+	account := s.findExchangeAccount(exchangeAccountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 0, "Exchange account not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *Server) getExchangeAsset(w http.ResponseWriter, exchangeAccountID, assetID string) {
+	account := s.findExchangeAccount(exchangeAccountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 0, "Exchange account not found")
+		return
+	}
+
+	asset := findExchangeAsset(account.Assets, assetID)
+	if asset == nil {
+		writeError(w, http.StatusNotFound, 0, "Asset not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, asset)
+}
+
+// exchangeInternalTransfer moves the balance between trading accounts, the funds stay on the exchange
+func (s *Server) exchangeInternalTransfer(w http.ResponseWriter, exchangeAccountID string, body []byte) {
+	account := s.findExchangeAccount(exchangeAccountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 0, "Exchange account not found")
+		return
+	}
+
+	req := &sdk.ExchangeTransferRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	amount, ok := parsePositiveAmount(w, req.Amount)
+	if !ok {
+		return
+	}
+
+	source, dest := findTradingAccount(account, req.SourceType), findTradingAccount(account, req.DestType)
+	if source == nil || dest == nil || source == dest {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("invalid trading accounts %s to %s", req.SourceType, req.DestType))
+		return
+	}
+
+	asset := findExchangeAsset(source.Assets, req.Asset)
+	if asset == nil {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("insufficient balance of %s", req.Asset))
+		return
+	}
+
+	if err := moveBalance(asset, &dest.Assets, req.Asset, amount); err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+}
+
+// convertExchangeAsset converts assets of the main account at 1:1 rate
+func (s *Server) convertExchangeAsset(w http.ResponseWriter, exchangeAccountID string, body []byte) {
+	account := s.findExchangeAccount(exchangeAccountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 0, "Exchange account not found")
+		return
+	}
+
+	req := &sdk.ExchangeConvertRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	amount, ok := parsePositiveAmount(w, req.Amount)
+	if !ok {
+		return
+	}
+
+	asset := findExchangeAsset(account.Assets, req.SrcAsset)
+	if asset == nil || req.DestAsset == "" || req.DestAsset == req.SrcAsset {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("cannot convert %s to %s", req.SrcAsset, req.DestAsset))
+		return
+	}
+
+	if err := moveBalance(asset, &account.Assets, req.DestAsset, amount); err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+}
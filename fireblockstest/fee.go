@@ -0,0 +1,40 @@
+package fireblockstest
+
+import (
+	"fmt"
+	"net/http"
+
+	sdk "fireblocksdk"
+)
+
+// SetFeeEstimate sets the estimate returned for the asset by /transactions/estimate_fee and /estimate_network_fee
+func (s *Server) SetFeeEstimate(assetID string, estimate sdk.EstimateFeeResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fees[assetID] = &estimate
+}
+
+func (s *Server) estimateTransactionFee(w http.ResponseWriter, body []byte) {
+	req := &sdk.TransactionRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.AssetID == "" || req.Source == nil {
+		writeError(w, http.StatusBadRequest, 0, "assetId and source are required")
+		return
+	}
+
+	s.getFeeEstimate(w, req.AssetID)
+}
+
+func (s *Server) getFeeEstimate(w http.ResponseWriter, assetID string) {
+	estimate, ok := s.fees[assetID]
+	if !ok {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("Fee estimation is not supported for %s", assetID))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, estimate)
+}
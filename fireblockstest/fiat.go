@@ -0,0 +1,108 @@
+package fireblockstest
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+
+	sdk "fireblocksdk"
+)
+
+// fiatAsset is the asset of the fiat account which redeem and deposit move
+const fiatAsset = "USD"
+
+// AddFiatAccount adds the fiat account and returns its ID, a new ID is generated when account.ID is empty
+func (s *Server) AddFiatAccount(account sdk.FiatAccountResponse) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account.ID == "" {
+		account.ID = newID()
+	}
+
+	if account.Type == "" {
+		account.Type = sdk.FiatAccountTypeBlinc
+	}
+
+	s.fiatAccounts = append(s.fiatAccounts, &account)
+
+	return account.ID
+}
+
+func (s *Server) findFiatAccount(fiatAccountID string) *sdk.FiatAccountResponse {
+	for _, account := range s.fiatAccounts {
+		if account.ID == fiatAccountID {
+			return account
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) getFiatAccounts(w http.ResponseWriter) {
+	accounts := s.fiatAccounts
+	if accounts == nil {
+		accounts = []*sdk.FiatAccountResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+func (s *Server) getFiatAccount(w http.ResponseWriter, fiatAccountID string) {
+	account := s.findFiatAccount(fiatAccountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 0, "Fiat account not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+// fiatTransfer changes the USD balance by the amount, redeem takes it out and deposit adds it
+func (s *Server) fiatTransfer(w http.ResponseWriter, fiatAccountID string, redeem bool, body []byte) {
+	account := s.findFiatAccount(fiatAccountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 0, "Fiat account not found")
+		return
+	}
+
+	req := &sdk.FiatTransferRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	amount, ok := parsePositiveAmount(w, req.Amount)
+	if !ok {
+		return
+	}
+
+	var asset *sdk.FiatAssetResponse
+	for _, a := range account.Assets {
+		if a.ID == fiatAsset {
+			asset = a
+		}
+	}
+
+	if asset == nil {
+		asset = &sdk.FiatAssetResponse{ID: fiatAsset, Balance: "0"}
+		account.Assets = append(account.Assets, asset)
+	}
+
+	balance, ok := new(big.Rat).SetString(asset.Balance)
+	if !ok {
+		balance = new(big.Rat)
+	}
+
+	if redeem {
+		if balance.Cmp(amount) < 0 {
+			writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("insufficient balance of %s", fiatAsset))
+			return
+		}
+
+		amount.Neg(amount)
+	}
+
+	asset.Balance = formatAmount(balance.Add(balance, amount))
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+}
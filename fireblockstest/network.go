@@ -0,0 +1,259 @@
+package fireblockstest
+
+import (
+	"net/http"
+
+	sdk "fireblocksdk"
+)
+
+// AddNetworkID adds the network ID, e.g. of the counterparty, and returns its ID
+func (s *Server) AddNetworkID(name string, isDiscoverable bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addNetworkID(name, isDiscoverable, sdk.RoutingPolicy{}).ID
+}
+
+func (s *Server) addNetworkID(name string, isDiscoverable bool, policy sdk.RoutingPolicy) *sdk.NetworkIDResponse {
+	networkID := &sdk.NetworkIDResponse{
+		ID:             newID(),
+		Name:           name,
+		IsDiscoverable: isDiscoverable,
+		RoutingPolicy:  sdk.RoutingPolicy{},
+	}
+
+	mergeRoutingPolicy(networkID.RoutingPolicy, policy)
+
+	s.networkIDs = append(s.networkIDs, networkID)
+
+	return networkID
+}
+
+func (s *Server) findNetworkID(networkID string) *sdk.NetworkIDResponse {
+	for _, n := range s.networkIDs {
+		if n.ID == networkID {
+			return n
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findNetworkConnection(connectionID string) *sdk.NetworkConnectionResponse {
+	for _, connection := range s.connections {
+		if connection.ID == connectionID {
+			return connection
+		}
+	}
+
+	return nil
+}
+
+// mergeRoutingPolicy replaces routing of the asset classes present in update, the others are kept
+func mergeRoutingPolicy(policy, update sdk.RoutingPolicy) {
+	for class, entry := range update {
+		copied := *entry
+		policy[class] = &copied
+	}
+}
+
+func (s *Server) getNetworkConnections(w http.ResponseWriter) {
+	connections := s.connections
+	if connections == nil {
+		connections = []*sdk.NetworkConnectionResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, connections)
+}
+
+func (s *Server) getNetworkConnection(w http.ResponseWriter, connectionID string) {
+	connection := s.findNetworkConnection(connectionID)
+	if connection == nil {
+		writeError(w, http.StatusNotFound, 0, "Network connection not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, connection)
+}
+
+// createNetworkConnection connects two known network IDs, the connection is approved at once unlike in Fireblocks
+func (s *Server) createNetworkConnection(w http.ResponseWriter, body []byte) {
+	req := &sdk.CreateNetworkConnectionRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	local, remote := s.findNetworkID(req.LocalNetworkID), s.findNetworkID(req.RemoteNetworkID)
+	if local == nil || remote == nil || local == remote {
+		writeError(w, http.StatusBadRequest, 0, "invalid network IDs")
+		return
+	}
+
+	if err := req.RoutingPolicy.ValidateForConnection(); err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+
+	connection := &sdk.NetworkConnectionResponse{
+		ID:              newID(),
+		LocalNetworkID:  sdk.NetworkIDInfo{ID: local.ID, Name: local.Name},
+		RemoteNetworkID: sdk.NetworkIDInfo{ID: remote.ID, Name: remote.Name},
+		RoutingPolicy:   sdk.RoutingPolicy{},
+		Status:          "APPROVED",
+	}
+
+	mergeRoutingPolicy(connection.RoutingPolicy, req.RoutingPolicy)
+
+	s.connections = append(s.connections, connection)
+
+	writeJSON(w, http.StatusOK, connection)
+}
+
+func (s *Server) deleteNetworkConnection(w http.ResponseWriter, connectionID string) {
+	for i, connection := range s.connections {
+		if connection.ID == connectionID {
+			s.connections = append(s.connections[:i:i], s.connections[i+1:]...)
+			writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 0, "Network connection not found")
+}
+
+func (s *Server) setNetworkConnectionRoutingPolicy(w http.ResponseWriter, connectionID string, body []byte) {
+	connection := s.findNetworkConnection(connectionID)
+	if connection == nil {
+		writeError(w, http.StatusNotFound, 0, "Network connection not found")
+		return
+	}
+
+	req := &sdk.SetRoutingPolicyRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if err := req.RoutingPolicy.ValidateForConnection(); err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+
+	mergeRoutingPolicy(connection.RoutingPolicy, req.RoutingPolicy)
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+}
+
+// isThirdPartyRouting answers false, the fake has no third party routing
+func (s *Server) isThirdPartyRouting(w http.ResponseWriter, connectionID string) {
+	if s.findNetworkConnection(connectionID) == nil {
+		writeError(w, http.StatusNotFound, 0, "Network connection not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &sdk.ThirdPartyRoutingResponse{IsThirdPartyRouting: false, Description: "Routed by the counterparty"})
+}
+
+func (s *Server) getNetworkIDs(w http.ResponseWriter) {
+	networkIDs := s.networkIDs
+	if networkIDs == nil {
+		networkIDs = []*sdk.NetworkIDResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, networkIDs)
+}
+
+func (s *Server) getNetworkID(w http.ResponseWriter, networkID string) {
+	n := s.findNetworkID(networkID)
+	if n == nil {
+		writeError(w, http.StatusNotFound, 0, "Network ID not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, n)
+}
+
+func (s *Server) createNetworkID(w http.ResponseWriter, body []byte) {
+	req := &sdk.CreateNetworkIDRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, 0, "name is required")
+		return
+	}
+
+	if err := req.RoutingPolicy.ValidateForNetworkID(); err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.addNetworkID(req.Name, false, req.RoutingPolicy))
+}
+
+func (s *Server) deleteNetworkID(w http.ResponseWriter, networkID string) {
+	for i, n := range s.networkIDs {
+		if n.ID == networkID {
+			s.networkIDs = append(s.networkIDs[:i:i], s.networkIDs[i+1:]...)
+			writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 0, "Network ID not found")
+}
+
+// networkIDAction applies PATCH /network_ids/{id}/{action}, it returns false for unknown actions
+func (s *Server) networkIDAction(w http.ResponseWriter, networkID, action string, body []byte) bool {
+	switch action {
+	case "set_routing_policy", "set_discoverability", "set_name":
+	default:
+		return false
+	}
+
+	n := s.findNetworkID(networkID)
+	if n == nil {
+		writeError(w, http.StatusNotFound, 0, "Network ID not found")
+		return true
+	}
+
+	switch action {
+	case "set_routing_policy":
+		req := &sdk.SetRoutingPolicyRequest{}
+		if !readJSON(w, body, req) {
+			return true
+		}
+
+		if err := req.RoutingPolicy.ValidateForNetworkID(); err != nil {
+			writeError(w, http.StatusBadRequest, 0, err.Error())
+			return true
+		}
+
+		mergeRoutingPolicy(n.RoutingPolicy, req.RoutingPolicy)
+	case "set_discoverability":
+		req := &sdk.SetDiscoverabilityRequest{}
+		if !readJSON(w, body, req) {
+			return true
+		}
+
+		n.IsDiscoverable = req.IsDiscoverable
+	case "set_name":
+		req := &sdk.SetNetworkIDNameRequest{}
+		if !readJSON(w, body, req) {
+			return true
+		}
+
+		if req.Name == "" {
+			writeError(w, http.StatusBadRequest, 0, "name is required")
+			return true
+		}
+
+		n.Name = req.Name
+	}
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+
+	return true
+}
@@ -0,0 +1,206 @@
+package fireblockstest
+
+import (
+	"net/http"
+
+	sdk "fireblocksdk"
+)
+
+// route dispatches the request by method and path segments, it returns false for unknown endpoints
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	switch segments[0] {
+	case "supported_assets":
+		if r.Method == http.MethodGet && len(segments) == 1 {
+			writeJSON(w, http.StatusOK, s.assets)
+			return true
+		}
+	case "vault":
+		return s.routeVault(w, r, segments[1:], body)
+	case "transactions":
+		return s.routeTransactions(w, r, segments[1:], body)
+	case "estimate_network_fee":
+		if r.Method == http.MethodGet && len(segments) == 1 {
+			s.getFeeEstimate(w, r.URL.Query().Get("assetId"))
+			return true
+		}
+	case "exchange_accounts":
+		return s.routeExchangeAccounts(w, r, segments[1:], body)
+	case "fiat_accounts":
+		return s.routeFiatAccounts(w, r, segments[1:], body)
+	case "internal_wallets", "external_wallets", "contracts":
+		return s.routeWallets(w, r, walletKinds[segments[0]], segments[1:], body)
+	case "network_connections":
+		return s.routeNetworkConnections(w, r, segments[1:], body)
+	case "network_ids":
+		return s.routeNetworkIDs(w, r, segments[1:], body)
+	}
+
+	return false
+}
+
+func (s *Server) routeVault(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	if len(segments) == 0 {
+		return false
+	}
+
+	switch {
+	case segments[0] == "accounts_paged" && len(segments) == 1 && r.Method == http.MethodGet:
+		s.getVaultAccountsPaged(w, r)
+	case segments[0] != "accounts":
+		return false
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getVaultAccounts(w, r)
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		s.createVaultAccount(w, body)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		s.getVaultAccount(w, segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPut:
+		s.renameVaultAccount(w, segments[1], body)
+	case len(segments) == 3 && r.Method == http.MethodPost && vaultAccountActions[segments[2]] != 0:
+		s.vaultAccountAction(w, segments[1], segments[2], body)
+	case len(segments) == 3 && r.Method == http.MethodPost:
+		s.createVaultAsset(w, segments[1], segments[2], body)
+	case len(segments) == 3 && r.Method == http.MethodGet:
+		s.getVaultAsset(w, segments[1], segments[2])
+	case len(segments) == 4 && segments[3] == "activate" && r.Method == http.MethodPost:
+		s.activateVaultAsset(w, segments[1], segments[2])
+	case len(segments) == 4 && segments[3] == "balance" && r.Method == http.MethodPost:
+		s.getVaultAsset(w, segments[1], segments[2])
+	case len(segments) == 4 && segments[3] == "max_spendable_amount" && r.Method == http.MethodGet:
+		s.getMaxSpendableAmount(w, segments[1], segments[2])
+	case len(segments) == 4 && segments[3] == "addresses" && r.Method == http.MethodGet:
+		s.getDepositAddresses(w, segments[1], segments[2])
+	case len(segments) == 4 && segments[3] == "addresses" && r.Method == http.MethodPost:
+		s.generateAddress(w, segments[1], segments[2], body)
+	case len(segments) == 4 && segments[3] == "unspent_inputs" && r.Method == http.MethodGet:
+		s.getUnspentInputs(w, segments[1], segments[2])
+	case len(segments) == 6 && segments[5] == "public_key_info" && r.Method == http.MethodGet:
+		s.getPublicKeyInfo(w, segments[1], segments[2], segments[3], segments[4])
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) routeTransactions(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.getTransactions(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createTransaction(w, body)
+	case len(segments) == 1 && segments[0] == "estimate_fee" && r.Method == http.MethodPost:
+		s.estimateTransactionFee(w, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getTransaction(w, segments[0])
+	case len(segments) == 2 && segments[0] == "external_tx_id" && r.Method == http.MethodGet:
+		s.getTransactionByExternalID(w, segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPost:
+		return s.transactionAction(w, segments[0], segments[1], body)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) routeExchangeAccounts(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.getExchangeAccounts(w)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getExchangeAccount(w, segments[0])
+	case len(segments) == 2 && segments[1] == "internal_transfer" && r.Method == http.MethodPost:
+		s.exchangeInternalTransfer(w, segments[0], body)
+	case len(segments) == 2 && segments[1] == "convert" && r.Method == http.MethodPost:
+		s.convertExchangeAsset(w, segments[0], body)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		s.getExchangeAsset(w, segments[0], segments[1])
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) routeFiatAccounts(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.getFiatAccounts(w)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getFiatAccount(w, segments[0])
+	case len(segments) == 2 && segments[1] == "redeem_to_linked_dda" && r.Method == http.MethodPost:
+		s.fiatTransfer(w, segments[0], true, body)
+	case len(segments) == 2 && segments[1] == "deposit_from_linked_dda" && r.Method == http.MethodPost:
+		s.fiatTransfer(w, segments[0], false, body)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) routeWallets(w http.ResponseWriter, r *http.Request, kind sdk.PeerType, segments []string, body []byte) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.getWallets(w, kind)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createWallet(w, kind, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getWallet(w, kind, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteWallet(w, kind, segments[0])
+	case len(segments) == 2 && segments[1] == "set_customer_ref_id" && kind != sdk.PeerTypeContract && r.Method == http.MethodPost:
+		s.setWalletCustomerRefID(w, kind, segments[0], body)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		s.getWalletAsset(w, kind, segments[0], segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPost:
+		s.addWalletAsset(w, kind, segments[0], segments[1], body)
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		s.deleteWalletAsset(w, kind, segments[0], segments[1])
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) routeNetworkConnections(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.getNetworkConnections(w)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createNetworkConnection(w, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getNetworkConnection(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteNetworkConnection(w, segments[0])
+	case len(segments) == 2 && segments[1] == "set_routing_policy" && r.Method == http.MethodPatch:
+		s.setNetworkConnectionRoutingPolicy(w, segments[0], body)
+	case len(segments) == 3 && segments[1] == "is_third_party_routing" && r.Method == http.MethodGet:
+		s.isThirdPartyRouting(w, segments[0])
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) routeNetworkIDs(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.getNetworkIDs(w)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createNetworkID(w, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getNetworkID(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteNetworkID(w, segments[0])
+	case len(segments) == 2 && r.Method == http.MethodPatch:
+		return s.networkIDAction(w, segments[0], segments[1], body)
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,268 @@
+// Package fireblockstest provides in-process fake of the Fireblocks API for offline tests.
+//
+//	srv := fireblockstest.NewServer()
+//	defer srv.Close()
+//
+//	fb, err := fireblocksdk.CreateSDK(srv.APIKey, srv.PrivateKeyPEM(), srv.URL)
+package fireblockstest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "fireblocksdk"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	DefaultAPIKey = "fireblockstest-api-key"
+
+	apiPrefix = "/v1"
+)
+
+// Server is the fake Fireblocks API, it keeps its state in memory
+// and accepts only requests signed the same way Fireblocks expects them.
+// POST request is applied once per Idempotency-Key, repeated key gets the recorded response.
+type Server struct {
+	*httptest.Server
+
+	APIKey     string
+	PrivateKey *rsa.PrivateKey
+
+	mu           sync.Mutex
+	now          func() time.Time
+	assets       []*sdk.AssetTypeResponse
+	accounts     []*sdk.VaultAccountResponse
+	addresses    map[string][]*sdk.DepositAddressResponse
+	utxos        map[string][]*sdk.UnspentInputsResponse
+	transactions []*sdk.TransactionResponse
+	exchanges    []*sdk.ExchangeAccountResponse
+	fiatAccounts []*sdk.FiatAccountResponse
+	wallets      map[sdk.PeerType][]*sdk.WalletResponse
+	networkIDs   []*sdk.NetworkIDResponse
+	connections  []*sdk.NetworkConnectionResponse
+	fees         map[string]*sdk.EstimateFeeResponse
+	requests     []*http.Request
+	idempotent   map[string]*httptest.ResponseRecorder
+}
+
+type ServerConfig struct {
+	apiKey     string
+	privateKey *rsa.PrivateKey
+	now        func() time.Time
+}
+
+// WithAPIKey sets the API key the server accepts, DefaultAPIKey is used otherwise
+func WithAPIKey(apiKey string) func(*ServerConfig) {
+	return func(c *ServerConfig) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithPrivateKey sets the API secret key, a new key is generated otherwise
+func WithPrivateKey(key *rsa.PrivateKey) func(*ServerConfig) {
+	return func(c *ServerConfig) {
+		c.privateKey = key
+	}
+}
+
+// WithClock replaces time.Now used for timestamps of transactions
+func WithClock(now func() time.Time) func(*ServerConfig) {
+	return func(c *ServerConfig) {
+		c.now = now
+	}
+}
+
+// NewServer starts the fake server, it must be closed by the caller
+func NewServer(configs ...func(*ServerConfig)) *Server {
+	cfg := &ServerConfig{apiKey: DefaultAPIKey, now: time.Now}
+	for _, conf := range configs {
+		conf(cfg)
+	}
+
+	if cfg.privateKey == nil {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(fmt.Sprintf("fireblockstest: failed to generate key: %v", err))
+		}
+
+		cfg.privateKey = key
+	}
+
+	s := &Server{
+		APIKey:     cfg.apiKey,
+		PrivateKey: cfg.privateKey,
+		now:        cfg.now,
+		addresses:  map[string][]*sdk.DepositAddressResponse{},
+		utxos:      map[string][]*sdk.UnspentInputsResponse{},
+		wallets:    map[sdk.PeerType][]*sdk.WalletResponse{},
+		fees:       map[string]*sdk.EstimateFeeResponse{},
+		idempotent: map[string]*httptest.ResponseRecorder{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// PrivateKeyPEM returns the API secret key in the form accepted by fireblocksdk.CreateSDK
+func (s *Server) PrivateKeyPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(s.PrivateKey),
+	})
+}
+
+// Requests returns all requests which passed authentication, bodies are already consumed
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*http.Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 0, "failed to read body")
+		return
+	}
+
+	if err := s.authenticate(r, body); err != nil {
+		writeError(w, http.StatusUnauthorized, -7, fmt.Sprintf("Unauthorized: %v", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r)
+
+	key := r.Header.Get("Idempotency-Key")
+	if r.Method != http.MethodPost || key == "" {
+		s.dispatch(w, r, body)
+		return
+	}
+
+	// the same key gets the recorded response, the request is not applied again
+	recorded, ok := s.idempotent[key]
+	if !ok {
+		recorded = httptest.NewRecorder()
+		s.dispatch(recorded, r, body)
+
+		if recorded.Code < http.StatusInternalServerError {
+			s.idempotent[key] = recorded
+		}
+	}
+
+	for name, values := range recorded.Header() {
+		w.Header()[name] = values
+	}
+
+	w.WriteHeader(recorded.Code)
+	_, _ = w.Write(recorded.Body.Bytes())
+}
+
+func (s *Server) dispatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	// IDs are path escaped by the SDK, so they are unescaped only after the path is split
+	path := strings.TrimPrefix(r.URL.EscapedPath(), apiPrefix)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	if !s.route(w, r, segments, body) {
+		writeError(w, http.StatusNotFound, 0, fmt.Sprintf("%s %s is not supported by fireblockstest", r.Method, r.URL.Path))
+	}
+}
+
+// authenticate validates the token the same way Fireblocks does: signature, exp, sub, uri and bodyHash
+func (s *Server) authenticate(r *http.Request, body []byte) error {
+	apiKey := r.Header.Get("X-API-Key")
+	if apiKey != s.APIKey {
+		return fmt.Errorf("unknown api key %q", apiKey)
+	}
+
+	bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(bearer, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+
+		return &s.PrivateKey.PublicKey, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return fmt.Errorf("exp is missing")
+	}
+
+	if claims["sub"] != s.APIKey {
+		return fmt.Errorf("sub %v does not match api key", claims["sub"])
+	}
+
+	if claims["uri"] != r.URL.RequestURI() {
+		return fmt.Errorf("uri %v does not match %s", claims["uri"], r.URL.RequestURI())
+	}
+
+	hash := sha256.Sum256(body)
+	if claims["bodyHash"] != hex.EncodeToString(hash[:]) {
+		return fmt.Errorf("bodyHash does not match body")
+	}
+
+	return nil
+}
+
+// newID generates random UUID v4, the format of Fireblocks transaction IDs
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+}
+
+func readJSON(w http.ResponseWriter, body []byte, v interface{}) bool {
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("invalid body: %v", err))
+		return false
+	}
+
+	return true
+}
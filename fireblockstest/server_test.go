@@ -0,0 +1,194 @@
+package fireblockstest_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
+
+type ServerSuite struct {
+	suite.Suite
+	srv *fireblockstest.Server
+	sdk *sdk.FireblocksSDK
+}
+
+func (suite *ServerSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+}
+
+func (suite *ServerSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *ServerSuite) TestRejectsForeignKey() {
+	other := fireblockstest.NewServer()
+	defer other.Close()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, other.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssets()
+	require.ErrorIs(suite.T(), err, sdk.ErrUnauthorized)
+	require.Empty(suite.T(), suite.srv.Requests())
+}
+
+func (suite *ServerSuite) TestSupportedAssets() {
+	decimals := int64(8)
+	suite.srv.AddSupportedAsset(sdk.AssetTypeResponse{ID: "BTC_TEST", Name: "Bitcoin Test", AssetType: "BASE_ASSET", Decimals: &decimals})
+
+	assets, err := suite.sdk.GetSupportedAssets()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), assets, 1)
+	require.Equal(suite.T(), "BTC_TEST", assets[0].ID)
+}
+
+func (suite *ServerSuite) TestVaultAccounts() {
+	created, err := suite.sdk.CreateVaultAccount("Test_treasury", "ref-1", nil, nil)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "0", created.ID)
+	require.Equal(suite.T(), "ref-1", *created.CustomerRefID)
+
+	suite.srv.AddVaultAccount("vault_UTX46")
+	require.NoError(suite.T(), suite.srv.SetAssetBalance(created.ID, "BTC_TEST", "6.5"))
+
+	accounts, err := suite.sdk.GetVaultAccounts(&sdk.VaultAccountsFilter{NamePrefix: "Test_"})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), accounts, 1)
+
+	accounts, err = suite.sdk.GetVaultAccounts(&sdk.VaultAccountsFilter{NameSuffix: "UTX46"})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), accounts, 1)
+
+	accounts, err = suite.sdk.GetVaultAccounts(&sdk.VaultAccountsFilter{MinAmountThreshold: "6.0", AssetID: "BTC_TEST"})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), accounts, 1)
+
+	account, err := suite.sdk.GetVaultAccountsByID(created.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "Test_treasury", account.Name)
+
+	asset, err := suite.sdk.GetVaultAccountAsset(created.ID, "BTC_TEST")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "6.5", asset.Total)
+
+	_, err = suite.sdk.GetVaultAccountsByID("42")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+
+	_, err = suite.sdk.GetVaultAccountAsset(created.ID, "ETH_TEST")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *ServerSuite) TestVaultAccountsPaged() {
+	for i := 0; i < 5; i++ {
+		suite.srv.AddVaultAccount("vault")
+	}
+
+	first, err := suite.sdk.GetVaultAccountsWithPageInfo(&sdk.PagedVaultAccountsRequestFilters{Limit: 2})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), first.Accounts, 2)
+	require.NotEmpty(suite.T(), first.NextURL)
+	require.Contains(suite.T(), first.NextURL, first.Paging.After)
+
+	second, err := suite.sdk.GetVaultAccountsWithPageInfo(&sdk.PagedVaultAccountsRequestFilters{Limit: 2, After: first.Paging.After})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "2", second.Accounts[0].ID)
+
+	previous, err := suite.sdk.GetVaultAccountsWithPageInfo(&sdk.PagedVaultAccountsRequestFilters{Limit: 2, Before: second.Paging.Before})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), first.Accounts, previous.Accounts)
+
+	all, err := suite.sdk.NewVaultAccountsPager(&sdk.PagedVaultAccountsRequestFilters{Limit: 2}).All(context.Background())
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), all, 5)
+}
+
+func (suite *ServerSuite) TestAddresses() {
+	accountID := suite.srv.AddVaultAccount("vault")
+	require.NoError(suite.T(), suite.srv.SetAssetBalance(accountID, "BTC_TEST", "0"))
+
+	generated, err := suite.sdk.GenerateNewAddress(accountID, "BTC_TEST", "deposit", "ref-1")
+	require.NoError(suite.T(), err)
+	require.NotEmpty(suite.T(), generated.Address)
+
+	addresses, err := suite.sdk.GetDepositAddresses(accountID, "BTC_TEST")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), addresses, 1)
+	require.Equal(suite.T(), generated.Address, addresses[0].Address)
+	require.Equal(suite.T(), "deposit", addresses[0].Description)
+
+	info, err := suite.sdk.GetPublicKeyInfoForVaultAccount(accountID, "BTC_TEST", 0, 1)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []int64{44, 0, 0, 0, 1}, info.DerivationPath)
+}
+
+func (suite *ServerSuite) TestTransactionLifecycle() {
+	accountID := suite.srv.AddVaultAccount("vault")
+
+	created, err := suite.sdk.CreateTransaction(&sdk.TransactionRequest{
+		AssetID:      "BTC_TEST",
+		Source:       &sdk.TransferPeerPath{Type: sdk.PeerTypeVaultAccount, ID: accountID},
+		Destination:  &sdk.TransferPeerPath{Type: sdk.PeerTypeOneTimeAddress, OneTimeAddress: &sdk.OneTimeAddress{Address: "tb1q"}},
+		Amount:       "0.1",
+		ExternalTxID: "ext-1",
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.TransactionStatusSubmitted, created.Status)
+
+	tx, err := suite.sdk.GetTransactionByExternalTxID("ext-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), created.ID, tx.ID)
+	require.Equal(suite.T(), "tb1q", tx.DestinationAddress)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = suite.srv.SetTransactionStatus(created.ID, sdk.TransactionStatusCompleted, "CONFIRMED")
+	}()
+
+	final, err := suite.sdk.WaitForTransaction(context.Background(), created.ID, sdk.WithPollInterval(5*time.Millisecond))
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.TransactionStatusCompleted, final.Status)
+
+	_, err = suite.sdk.CancelTransactionByID(created.ID)
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	listed, err := suite.sdk.GetTransactions(&sdk.TransactionsFilter{Status: "COMPLETED"})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), listed, 1)
+
+	listed, err = suite.sdk.GetTransactions(&sdk.TransactionsFilter{Status: "FAILED"})
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), listed)
+}
+
+func (suite *ServerSuite) TestCancelTransaction() {
+	accountID := suite.srv.AddVaultAccount("vault")
+
+	created, err := suite.sdk.CreateTransaction(&sdk.TransactionRequest{
+		AssetID: "BTC_TEST",
+		Source:  &sdk.TransferPeerPath{Type: sdk.PeerTypeVaultAccount, ID: accountID},
+		Amount:  "0.1",
+	})
+	require.NoError(suite.T(), err)
+
+	resp, err := suite.sdk.CancelTransactionByID(created.ID)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+
+	tx, ok := suite.srv.Transaction(created.ID)
+	require.True(suite.T(), ok)
+	require.Equal(suite.T(), sdk.TransactionStatusCancelled, tx.Status)
+}
@@ -0,0 +1,268 @@
+package fireblockstest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdk "fireblocksdk"
+)
+
+const defaultTransactionsLimit = 200
+
+// Transaction returns copy of the stored transaction
+func (s *Server) Transaction(txID string) (sdk.TransactionResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.findTransaction(txID)
+	if tx == nil {
+		return sdk.TransactionResponse{}, false
+	}
+
+	return *tx, true
+}
+
+// SetTransactionStatus moves the transaction to status, the way Fireblocks does while processing it
+func (s *Server) SetTransactionStatus(txID string, status sdk.TransactionStatus, subStatus string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.findTransaction(txID)
+	if tx == nil {
+		return fmt.Errorf("transaction %s not found", txID)
+	}
+
+	tx.Status = status
+	tx.SubStatus = subStatus
+	tx.LastUpdated = s.now().UnixMilli()
+
+	return nil
+}
+
+func (s *Server) findTransaction(txID string) *sdk.TransactionResponse {
+	for _, tx := range s.transactions {
+		if tx.ID == txID {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+func peerPathResponse(peer *sdk.TransferPeerPath) sdk.TransferPeerPathResponse {
+	if peer == nil {
+		return sdk.TransferPeerPathResponse{}
+	}
+
+	return sdk.TransferPeerPathResponse{Type: peer.Type, ID: peer.ID}
+}
+
+func (s *Server) addTransaction(req *sdk.TransactionRequest) *sdk.TransactionResponse {
+	now := s.now().UnixMilli()
+
+	operation := req.Operation
+	if operation == "" {
+		operation = sdk.TransactionOperationTransfer
+	}
+
+	tx := &sdk.TransactionResponse{
+		ID:              newID(),
+		AssetID:         req.AssetID,
+		Source:          peerPathResponse(req.Source),
+		Destination:     peerPathResponse(req.Destination),
+		AmountInfo:      &sdk.AmountInfo{Amount: req.Amount, RequestedAmount: req.Amount},
+		CreatedAt:       now,
+		LastUpdated:     now,
+		Status:          sdk.TransactionStatusSubmitted,
+		Note:            req.Note,
+		Operation:       operation,
+		ExternalTxID:    req.ExternalTxID,
+		CustomerRefID:   req.CustomerRefID,
+		ExtraParameters: req.ExtraParameters,
+	}
+
+	if req.Destination != nil && req.Destination.OneTimeAddress != nil {
+		tx.DestinationAddress = req.Destination.OneTimeAddress.Address
+		tx.DestinationTag = req.Destination.OneTimeAddress.Tag
+	} else {
+		tx.DestinationAddress, tx.DestinationTag = s.walletAddress(req.Destination, req.AssetID)
+	}
+
+	if amount, err := strconv.ParseFloat(req.Amount, 64); err == nil {
+		tx.Amount = amount
+		tx.RequestedAmount = amount
+	}
+
+	s.transactions = append(s.transactions, tx)
+
+	return tx
+}
+
+func (s *Server) createTransaction(w http.ResponseWriter, body []byte) {
+	req := &sdk.TransactionRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.AssetID == "" || req.Source == nil {
+		writeError(w, http.StatusBadRequest, 0, "assetId and source are required")
+		return
+	}
+
+	if req.Source.Type == sdk.PeerTypeVaultAccount && s.findVaultAccount(req.Source.ID) == nil {
+		writeError(w, http.StatusBadRequest, 1004, "Vault account not found")
+		return
+	}
+
+	if req.ExternalTxID != "" {
+		for _, tx := range s.transactions {
+			if tx.ExternalTxID == req.ExternalTxID {
+				writeError(w, http.StatusBadRequest, 0, "externalTxId already exists")
+				return
+			}
+		}
+	}
+
+	tx := s.addTransaction(req)
+
+	writeJSON(w, http.StatusOK, &sdk.CreateTransactionResponse{ID: tx.ID, Status: tx.Status})
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, txID string) {
+	tx := s.findTransaction(txID)
+	if tx == nil {
+		writeError(w, http.StatusNotFound, 0, "Transaction not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) getTransactionByExternalID(w http.ResponseWriter, externalTxID string) {
+	for _, tx := range s.transactions {
+		if tx.ExternalTxID == externalTxID {
+			writeJSON(w, http.StatusOK, tx)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 0, "Transaction not found")
+}
+
+func matchesList(list, value string) bool {
+	if list == "" {
+		return true
+	}
+
+	for _, item := range strings.Split(list, ",") {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) getTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	before, _ := strconv.ParseInt(q.Get("before"), 10, 64)
+	after, _ := strconv.ParseInt(q.Get("after"), 10, 64)
+
+	result := []*sdk.TransactionResponse{}
+
+	for _, tx := range s.transactions {
+		switch {
+		case !matchesList(q.Get("status"), string(tx.Status)),
+			!matchesList(q.Get("assets"), tx.AssetID),
+			!matchesList(q.Get("sourceType"), string(tx.Source.Type)),
+			!matchesList(q.Get("sourceId"), tx.Source.ID),
+			!matchesList(q.Get("destType"), string(tx.Destination.Type)),
+			!matchesList(q.Get("destId"), tx.Destination.ID),
+			!matchesList(q.Get("txHash"), tx.TxHash),
+			before > 0 && tx.CreatedAt >= before,
+			after > 0 && tx.CreatedAt <= after:
+			continue
+		}
+
+		result = append(result, tx)
+	}
+
+	key := func(tx *sdk.TransactionResponse) int64 {
+		if q.Get("orderBy") == "lastUpdated" {
+			return tx.LastUpdated
+		}
+
+		return tx.CreatedAt
+	}
+
+	ascending := q.Get("sort") == "ASC"
+	sort.SliceStable(result, func(i, j int) bool {
+		if ascending {
+			return key(result[i]) < key(result[j])
+		}
+
+		return key(result[i]) > key(result[j])
+	})
+
+	limit := defaultTransactionsLimit
+	if parsed, err := strconv.Atoi(q.Get("limit")); err == nil && parsed > 0 {
+		limit = parsed
+	}
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) transactionAction(w http.ResponseWriter, txID, action string, body []byte) bool {
+	switch action {
+	case "cancel", "freeze", "unfreeze", "drop":
+	default:
+		return false
+	}
+
+	tx := s.findTransaction(txID)
+	if tx == nil {
+		writeError(w, http.StatusNotFound, 0, "Transaction not found")
+		return true
+	}
+
+	switch action {
+	case "cancel":
+		if tx.Status.IsTerminal() {
+			writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("Transaction is already %s", tx.Status))
+			return true
+		}
+
+		tx.Status = sdk.TransactionStatusCancelled
+		tx.LastUpdated = s.now().UnixMilli()
+	case "drop":
+		req := &sdk.DropTransactionRequest{}
+		if !readJSON(w, body, req) {
+			return true
+		}
+
+		replacement := s.addTransaction(&sdk.TransactionRequest{
+			AssetID:     tx.AssetID,
+			Source:      &sdk.TransferPeerPath{Type: tx.Source.Type, ID: tx.Source.ID},
+			Destination: &sdk.TransferPeerPath{Type: tx.Source.Type, ID: tx.Source.ID},
+			Amount:      "0",
+			FeeLevel:    req.FeeLevel,
+		})
+		replacement.ReplacedTxHash = tx.TxHash
+
+		writeJSON(w, http.StatusOK, &sdk.DropTransactionResponse{Success: true, Transactions: []string{replacement.ID}})
+
+		return true
+	}
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+
+	return true
+}
@@ -0,0 +1,527 @@
+package fireblockstest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	sdk "fireblocksdk"
+)
+
+const (
+	defaultPageLimit = 300
+	maxPageLimit     = 500
+)
+
+// AddSupportedAsset adds asset to /supported_assets
+func (s *Server) AddSupportedAsset(asset sdk.AssetTypeResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assets = append(s.assets, &asset)
+}
+
+// AddVaultAccount creates vault account and returns its ID
+func (s *Server) AddVaultAccount(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addVaultAccount(&sdk.VaultAccountRequest{Name: name}).ID
+}
+
+// SetAssetBalance sets total and available balance of the vault asset, the asset is created when missing
+func (s *Server) SetAssetBalance(accountID, assetID, total string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.findVaultAccount(accountID)
+	if account == nil {
+		return fmt.Errorf("vault account %s not found", accountID)
+	}
+
+	asset := findAsset(account, assetID)
+	if asset == nil {
+		asset = &sdk.AssetResponse{ID: assetID}
+		account.Assets = append(account.Assets, asset)
+	}
+
+	asset.Total = total
+	asset.Available = total
+
+	return nil
+}
+
+// AddUnspentInput adds UTXO returned by /unspent_inputs of the vault asset, the asset must exist
+func (s *Server) AddUnspentInput(accountID, assetID string, utxo sdk.UnspentInputsResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.findVaultAccount(accountID)
+	if account == nil || findAsset(account, assetID) == nil {
+		return fmt.Errorf("vault asset %s/%s not found", accountID, assetID)
+	}
+
+	key := accountID + "/" + assetID
+	s.utxos[key] = append(s.utxos[key], &utxo)
+
+	return nil
+}
+
+func (s *Server) addVaultAccount(req *sdk.VaultAccountRequest) *sdk.VaultAccountResponse {
+	account := &sdk.VaultAccountResponse{
+		ID:         strconv.Itoa(len(s.accounts)),
+		Name:       req.Name,
+		Assets:     []*sdk.AssetResponse{},
+		AutoFuel:   boolPtr(req.AutoFuel != nil && *req.AutoFuel),
+		HiddenOnUI: boolPtr(req.HiddenOnUI != nil && *req.HiddenOnUI),
+	}
+
+	if req.CustomerRefID != "" {
+		customerRefID := req.CustomerRefID
+		account.CustomerRefID = &customerRefID
+	}
+
+	s.accounts = append(s.accounts, account)
+
+	return account
+}
+
+func (s *Server) findVaultAccount(accountID string) *sdk.VaultAccountResponse {
+	for _, account := range s.accounts {
+		if account.ID == accountID {
+			return account
+		}
+	}
+
+	return nil
+}
+
+func findAsset(account *sdk.VaultAccountResponse, assetID string) *sdk.AssetResponse {
+	for _, asset := range account.Assets {
+		if asset.ID == assetID {
+			return asset
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) filterVaultAccounts(q url.Values) []*sdk.VaultAccountResponse {
+	var result []*sdk.VaultAccountResponse
+
+	for _, account := range s.accounts {
+		if prefix := q.Get("namePrefix"); prefix != "" && !strings.HasPrefix(account.Name, prefix) {
+			continue
+		}
+
+		if suffix := q.Get("nameSuffix"); suffix != "" && !strings.HasSuffix(account.Name, suffix) {
+			continue
+		}
+
+		if assetID := q.Get("assetId"); assetID != "" && findAsset(account, assetID) == nil {
+			continue
+		}
+
+		if threshold := q.Get("minAmountThreshold"); threshold != "" && !hasAmountOver(account, threshold) {
+			continue
+		}
+
+		result = append(result, account)
+	}
+
+	return result
+}
+
+func hasAmountOver(account *sdk.VaultAccountResponse, threshold string) bool {
+	min, ok := new(big.Rat).SetString(threshold)
+	if !ok {
+		return false
+	}
+
+	for _, asset := range account.Assets {
+		total, ok := new(big.Rat).SetString(asset.Total)
+		if ok && total.Cmp(min) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) getVaultAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts := s.filterVaultAccounts(r.URL.Query())
+	if accounts == nil {
+		accounts = []*sdk.VaultAccountResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+// getVaultAccountsPaged uses IDs of the accounts as before and after cursors
+func (s *Server) getVaultAccountsPaged(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	accounts := s.filterVaultAccounts(q)
+
+	if q.Get("orderBy") == "DESC" {
+		for i, j := 0, len(accounts)-1; i < j; i, j = i+1, j-1 {
+			accounts[i], accounts[j] = accounts[j], accounts[i]
+		}
+	}
+
+	limit := defaultPageLimit
+	if value := q.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxPageLimit {
+			writeError(w, http.StatusBadRequest, 1000, "invalid limit")
+			return
+		}
+
+		limit = parsed
+	}
+
+	start, end := 0, len(accounts)
+
+	switch {
+	case q.Get("after") != "":
+		start = indexOfAccount(accounts, q.Get("after")) + 1
+		if start == 0 {
+			writeError(w, http.StatusBadRequest, 1000, "invalid after cursor")
+			return
+		}
+
+		if start+limit < end {
+			end = start + limit
+		}
+	case q.Get("before") != "":
+		end = indexOfAccount(accounts, q.Get("before"))
+		if end < 0 {
+			writeError(w, http.StatusBadRequest, 1000, "invalid before cursor")
+			return
+		}
+
+		if end-limit > start {
+			start = end - limit
+		}
+	default:
+		if limit < end {
+			end = limit
+		}
+	}
+
+	resp := &sdk.PagedVaultAccountsResponse{Accounts: []sdk.VaultAccountResponse{}}
+	for _, account := range accounts[start:end] {
+		resp.Accounts = append(resp.Accounts, *account)
+	}
+
+	page := url.Values{}
+	for key := range q {
+		if key != "after" && key != "before" {
+			page.Set(key, q.Get(key))
+		}
+	}
+
+	if start > 0 && start < len(accounts) {
+		resp.Paging.Before = accounts[start].ID
+		page.Set("before", resp.Paging.Before)
+		resp.PreviousURL = fmt.Sprintf("%s%s/vault/accounts_paged?%s", s.URL, apiPrefix, page.Encode())
+		page.Del("before")
+	}
+
+	if end < len(accounts) && end > 0 {
+		resp.Paging.After = accounts[end-1].ID
+		page.Set("after", resp.Paging.After)
+		resp.NextURL = fmt.Sprintf("%s%s/vault/accounts_paged?%s", s.URL, apiPrefix, page.Encode())
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func indexOfAccount(accounts []*sdk.VaultAccountResponse, accountID string) int {
+	for i, account := range accounts {
+		if account.ID == accountID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) createVaultAccount(w http.ResponseWriter, body []byte) {
+	req := &sdk.VaultAccountRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, 1002, "name is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.addVaultAccount(req))
+}
+
+func (s *Server) getVaultAccount(w http.ResponseWriter, accountID string) {
+	account := s.findVaultAccount(accountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 1004, "Vault account not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *Server) renameVaultAccount(w http.ResponseWriter, accountID string, body []byte) {
+	req := &sdk.UpdateVaultAccountRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, 1013, "name is required")
+		return
+	}
+
+	account := s.findVaultAccount(accountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 1004, "Vault account not found")
+		return
+	}
+
+	account.Name = req.Name
+
+	writeJSON(w, http.StatusOK, &sdk.RenameVaultAccountResponse{ID: account.ID, Name: account.Name})
+}
+
+// vaultAccountActions maps the action to the error code returned for the missing account
+var vaultAccountActions = map[string]int{"hide": 1017, "unhide": 1020, "set_auto_fuel": 1004, "set_customer_ref_id": 1004}
+
+func (s *Server) vaultAccountAction(w http.ResponseWriter, accountID, action string, body []byte) {
+	account := s.findVaultAccount(accountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, vaultAccountActions[action], "Vault account not found")
+		return
+	}
+
+	switch action {
+	case "hide":
+		account.HiddenOnUI = boolPtr(true)
+	case "unhide":
+		account.HiddenOnUI = boolPtr(false)
+	case "set_auto_fuel":
+		req := &sdk.SetAutoFuelRequest{}
+		if !readJSON(w, body, req) {
+			return
+		}
+
+		account.AutoFuel = boolPtr(req.AutoFuel)
+	case "set_customer_ref_id":
+		req := &sdk.SetCustomerRefIDRequest{}
+		if !readJSON(w, body, req) {
+			return
+		}
+
+		account.CustomerRefID = nil
+		if req.CustomerRefID != "" {
+			account.CustomerRefID = &req.CustomerRefID
+		}
+	}
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+}
+
+// isSupportedAsset accepts any asset until the supported assets are added with AddSupportedAsset
+func (s *Server) isSupportedAsset(assetID string) bool {
+	if len(s.assets) == 0 {
+		return true
+	}
+
+	for _, asset := range s.assets {
+		if asset.ID == assetID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) createVaultAsset(w http.ResponseWriter, accountID, assetID string, body []byte) {
+	req := &sdk.CreateVaultAssetRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	account := s.findVaultAccount(accountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 1004, "Vault account not found")
+		return
+	}
+
+	if !s.isSupportedAsset(assetID) {
+		writeError(w, http.StatusBadRequest, 1025, "Asset is not supported")
+		return
+	}
+
+	if findAsset(account, assetID) != nil {
+		writeError(w, http.StatusBadRequest, 1008, "Vault asset already exists")
+		return
+	}
+
+	account.Assets = append(account.Assets, &sdk.AssetResponse{ID: assetID, Total: "0", Available: "0", Pending: "0"})
+
+	key := accountID + "/" + assetID
+	address := &sdk.DepositAddressResponse{
+		AssetID:       assetID,
+		Address:       fmt.Sprintf("fireblockstest-%s-%s-0", assetID, accountID),
+		TypeAddress:   "Permanent",
+		AddressFormat: "SEGWIT",
+	}
+	s.addresses[key] = append(s.addresses[key], address)
+
+	writeJSON(w, http.StatusOK, &sdk.CreateVaultAssetResponse{
+		ID:             assetID,
+		Address:        address.Address,
+		EosAccountName: req.EosAccountName,
+	})
+}
+
+// activateVaultAsset answers with the ID of the activation transaction, which is created in SUBMITTED status
+func (s *Server) activateVaultAsset(w http.ResponseWriter, accountID, assetID string) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset == nil {
+		return
+	}
+
+	tx := s.addTransaction(&sdk.TransactionRequest{
+		AssetID: assetID,
+		Source:  &sdk.TransferPeerPath{Type: sdk.PeerTypeVaultAccount, ID: accountID},
+		Amount:  "0",
+	})
+
+	resp := &sdk.CreateVaultAssetResponse{ID: assetID, ActivationTxID: tx.ID}
+	if addresses := s.addresses[accountID+"/"+assetID]; len(addresses) > 0 {
+		resp.Address = addresses[0].Address
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// getMaxSpendableAmount answers with the available balance, fees are not modelled
+func (s *Server) getMaxSpendableAmount(w http.ResponseWriter, accountID, assetID string) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset != nil {
+		writeJSON(w, http.StatusOK, &sdk.MaxSpendableAmountResponse{MaxSpendableAmount: asset.Available})
+	}
+}
+
+// vaultAsset writes the error and returns nil when the account or the asset does not exist
+func (s *Server) vaultAsset(w http.ResponseWriter, accountID, assetID string) *sdk.AssetResponse {
+	account := s.findVaultAccount(accountID)
+	if account == nil {
+		writeError(w, http.StatusNotFound, 1004, "Vault account not found")
+		return nil
+	}
+
+	asset := findAsset(account, assetID)
+	if asset == nil {
+		writeError(w, http.StatusNotFound, 1006, "Vault asset not found")
+		return nil
+	}
+
+	return asset
+}
+
+func (s *Server) getVaultAsset(w http.ResponseWriter, accountID, assetID string) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset != nil {
+		writeJSON(w, http.StatusOK, asset)
+	}
+}
+
+func (s *Server) getDepositAddresses(w http.ResponseWriter, accountID, assetID string) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset == nil {
+		return
+	}
+
+	addresses := s.addresses[accountID+"/"+assetID]
+	if addresses == nil {
+		addresses = []*sdk.DepositAddressResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, addresses)
+}
+
+func (s *Server) generateAddress(w http.ResponseWriter, accountID, assetID string, body []byte) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset == nil {
+		return
+	}
+
+	req := &sdk.PostOptions{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	key := accountID + "/" + assetID
+	index := len(s.addresses[key])
+
+	address := &sdk.DepositAddressResponse{
+		AssetID:           assetID,
+		Address:           fmt.Sprintf("fireblockstest-%s-%s-%d", assetID, accountID, index),
+		Description:       req.Description,
+		CustomerRefID:     req.CustomerRefID,
+		TypeAddress:       "Permanent",
+		AddressFormat:     "SEGWIT",
+		Bip44AddressIndex: index,
+	}
+
+	s.addresses[key] = append(s.addresses[key], address)
+
+	writeJSON(w, http.StatusOK, &sdk.GenerateAddressResponse{
+		Address:           address.Address,
+		Bip44AddressIndex: &index,
+	})
+}
+
+func (s *Server) getUnspentInputs(w http.ResponseWriter, accountID, assetID string) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset == nil {
+		return
+	}
+
+	utxos := s.utxos[accountID+"/"+assetID]
+	if utxos == nil {
+		utxos = []*sdk.UnspentInputsResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, utxos)
+}
+
+func (s *Server) getPublicKeyInfo(w http.ResponseWriter, accountID, assetID, change, index string) {
+	if asset := s.vaultAsset(w, accountID, assetID); asset == nil {
+		return
+	}
+
+	path := []int64{44, 0}
+	for _, value := range []string{accountID, change, index} {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, 0, "invalid derivation path")
+			return
+		}
+
+		path = append(path, parsed)
+	}
+
+	// not a real key, only stable for the same derivation path
+	hash := sha256.Sum256([]byte(fmt.Sprint(assetID, path)))
+
+	writeJSON(w, http.StatusOK, &sdk.PublicKeyInfoResponse{
+		PublicKey:      "02" + hex.EncodeToString(hash[:]),
+		Algorithm:      "MPC_ECDSA_SECP256K1",
+		DerivationPath: path,
+	})
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,206 @@
+package fireblockstest
+
+import (
+	"fmt"
+	"net/http"
+
+	sdk "fireblocksdk"
+)
+
+// walletKinds maps the path of the wallets endpoint to the peer type of its wallets
+var walletKinds = map[string]sdk.PeerType{
+	"internal_wallets": sdk.PeerTypeInternalWallet,
+	"external_wallets": sdk.PeerTypeExternalWallet,
+	"contracts":        sdk.PeerTypeContract,
+}
+
+func (s *Server) findWallet(kind sdk.PeerType, walletID string) *sdk.WalletResponse {
+	for _, wallet := range s.wallets[kind] {
+		if wallet.ID == walletID {
+			return wallet
+		}
+	}
+
+	return nil
+}
+
+func findWalletAsset(wallet *sdk.WalletResponse, assetID string) *sdk.WalletAssetResponse {
+	for _, asset := range wallet.Assets {
+		if asset.ID == assetID {
+			return asset
+		}
+	}
+
+	return nil
+}
+
+// walletAddress resolves the whitelisted address the transaction is sent to, empty when the peer is not a wallet
+func (s *Server) walletAddress(peer *sdk.TransferPeerPath, assetID string) (string, string) {
+	if peer == nil {
+		return "", ""
+	}
+
+	wallet := s.findWallet(peer.Type, peer.ID)
+	if wallet == nil {
+		return "", ""
+	}
+
+	asset := findWalletAsset(wallet, assetID)
+	if asset == nil {
+		return "", ""
+	}
+
+	return asset.Address, asset.Tag
+}
+
+func (s *Server) getWallets(w http.ResponseWriter, kind sdk.PeerType) {
+	wallets := s.wallets[kind]
+	if wallets == nil {
+		wallets = []*sdk.WalletResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, wallets)
+}
+
+func (s *Server) getWallet(w http.ResponseWriter, kind sdk.PeerType, walletID string) {
+	wallet := s.findWallet(kind, walletID)
+	if wallet == nil {
+		writeError(w, http.StatusNotFound, 0, "Wallet not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) createWallet(w http.ResponseWriter, kind sdk.PeerType, body []byte) {
+	req := &sdk.CreateWalletRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, 0, "name is required")
+		return
+	}
+
+	if kind == sdk.PeerTypeContract && req.CustomerRefID != "" {
+		writeError(w, http.StatusBadRequest, 0, "customerRefId is not supported by contracts")
+		return
+	}
+
+	wallet := &sdk.WalletResponse{
+		ID:            newID(),
+		Name:          req.Name,
+		CustomerRefID: req.CustomerRefID,
+		Assets:        []*sdk.WalletAssetResponse{},
+	}
+
+	s.wallets[kind] = append(s.wallets[kind], wallet)
+
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) deleteWallet(w http.ResponseWriter, kind sdk.PeerType, walletID string) {
+	wallets := s.wallets[kind]
+	for i, wallet := range wallets {
+		if wallet.ID == walletID {
+			s.wallets[kind] = append(wallets[:i:i], wallets[i+1:]...)
+			writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 0, "Wallet not found")
+}
+
+func (s *Server) setWalletCustomerRefID(w http.ResponseWriter, kind sdk.PeerType, walletID string, body []byte) {
+	wallet := s.findWallet(kind, walletID)
+	if wallet == nil {
+		writeError(w, http.StatusNotFound, 0, "Wallet not found")
+		return
+	}
+
+	req := &sdk.SetCustomerRefIDRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	wallet.CustomerRefID = req.CustomerRefID
+
+	writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+}
+
+func (s *Server) getWalletAsset(w http.ResponseWriter, kind sdk.PeerType, walletID, assetID string) {
+	wallet := s.findWallet(kind, walletID)
+	if wallet == nil {
+		writeError(w, http.StatusNotFound, 0, "Wallet not found")
+		return
+	}
+
+	asset := findWalletAsset(wallet, assetID)
+	if asset == nil {
+		writeError(w, http.StatusNotFound, 0, "Asset not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, asset)
+}
+
+// addWalletAsset whitelists the address, it is approved at once unlike in Fireblocks
+func (s *Server) addWalletAsset(w http.ResponseWriter, kind sdk.PeerType, walletID, assetID string, body []byte) {
+	wallet := s.findWallet(kind, walletID)
+	if wallet == nil {
+		writeError(w, http.StatusNotFound, 0, "Wallet not found")
+		return
+	}
+
+	req := &sdk.WalletAssetRequest{}
+	if !readJSON(w, body, req) {
+		return
+	}
+
+	if req.Address == "" {
+		writeError(w, http.StatusBadRequest, 0, "address is required")
+		return
+	}
+
+	if findWalletAsset(wallet, assetID) != nil {
+		writeError(w, http.StatusBadRequest, 0, fmt.Sprintf("Asset %s already exists in the wallet", assetID))
+		return
+	}
+
+	asset := &sdk.WalletAssetResponse{
+		ID:      assetID,
+		Status:  sdk.WalletAssetStatusApproved,
+		Address: req.Address,
+		Tag:     req.Tag,
+	}
+
+	if kind == sdk.PeerTypeInternalWallet {
+		asset.Balance = "0"
+	}
+
+	wallet.Assets = append(wallet.Assets, asset)
+
+	writeJSON(w, http.StatusOK, asset)
+}
+
+func (s *Server) deleteWalletAsset(w http.ResponseWriter, kind sdk.PeerType, walletID, assetID string) {
+	wallet := s.findWallet(kind, walletID)
+	if wallet == nil {
+		writeError(w, http.StatusNotFound, 0, "Wallet not found")
+		return
+	}
+
+	for i, asset := range wallet.Assets {
+		if asset.ID == assetID {
+			wallet.Assets = append(wallet.Assets[:i:i], wallet.Assets[i+1:]...)
+			writeJSON(w, http.StatusOK, &sdk.OperationSuccessResponse{Success: true})
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 0, "Asset not found")
+}
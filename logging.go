@@ -0,0 +1,106 @@
+package fireblocksdk
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never written to the log
+var sensitiveHeaders = []string{"Authorization", "X-API-Key"}
+
+type (
+	attemptCounterKey struct{}
+	attemptKey        struct{}
+)
+
+// withAttempts adds the counter of attempts which is shared by all retries of the request
+func withAttempts(ctx context.Context) context.Context {
+	return context.WithValue(ctx, attemptCounterKey{}, new(int32))
+}
+
+// nextAttempt counts the attempt of the request and stores its number in ctx, the first attempt is 1
+func nextAttempt(ctx context.Context) (context.Context, int) {
+	attempt := 1
+	if counter, ok := ctx.Value(attemptCounterKey{}).(*int32); ok {
+		attempt = int(atomic.AddInt32(counter, 1))
+	}
+
+	return context.WithValue(ctx, attemptKey{}, attempt), attempt
+}
+
+// attemptFromContext returns the number of the attempt set by nextAttempt
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+
+	return 1
+}
+
+func redactHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	for _, key := range sensitiveHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, redacted)
+		}
+	}
+
+	return header
+}
+
+// loggingTransport logs every attempt of the request with its outcome and latency
+type loggingTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attemptFromContext(ctx)),
+	}
+
+	t.logger.LogAttrs(ctx, slog.LevelDebug, "fireblocks request", append(attrs, slog.Any("headers", redactHeaders(req.Header)))...)
+
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs = append(attrs, slog.Duration("latency", time.Since(started)))
+
+	if err != nil {
+		level := slog.LevelError
+		if ctx.Err() != nil {
+			level = slog.LevelDebug
+		}
+
+		t.logger.LogAttrs(ctx, level, "fireblocks request failed", append(attrs, slog.Any("error", err))...)
+
+		return resp, err
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.String("request_id", resp.Header.Get("x-request-id")),
+	)
+
+	t.logger.LogAttrs(ctx, responseLevel(resp.StatusCode), "fireblocks response", attrs...)
+
+	return resp, nil
+}
+
+func responseLevel(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelDebug
+	}
+}
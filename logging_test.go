@@ -0,0 +1,99 @@
+package fireblocksdk_test
+
+import (
+	"bytes"
+	"encoding/json"
+	sdk "fireblocksdk"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLogs(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestLoggerReceivesStructuredAttempts(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req-1")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	fb, err := sdk.CreateSDK("secret-api-key", []byte(privateKey), server.URL,
+		sdk.WithLogger(logger),
+		sdk.WithRetryWait(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	_, err = fb.GetSupportedAssets()
+	require.NoError(t, err)
+
+	require.NotContains(t, buf.String(), "secret-api-key")
+	require.NotContains(t, buf.String(), "Bearer")
+
+	var responses, requests []map[string]interface{}
+	for _, record := range decodeLogs(t, buf) {
+		switch record["msg"] {
+		case "fireblocks response":
+			responses = append(responses, record)
+		case "fireblocks request":
+			requests = append(requests, record)
+		}
+	}
+
+	require.Len(t, requests, 2)
+	headers := requests[0]["headers"].(map[string]interface{})
+	require.Equal(t, []interface{}{"REDACTED"}, headers["Authorization"])
+	require.Equal(t, []interface{}{"REDACTED"}, headers["X-Api-Key"])
+
+	require.Len(t, responses, 2)
+	require.Equal(t, "ERROR", responses[0]["level"])
+	require.Equal(t, float64(http.StatusServiceUnavailable), responses[0]["status"])
+	require.Equal(t, float64(1), responses[0]["attempt"])
+
+	require.Equal(t, "DEBUG", responses[1]["level"])
+	require.Equal(t, "GET", responses[1]["method"])
+	require.Equal(t, "/v1/supported_assets", responses[1]["path"])
+	require.Equal(t, float64(http.StatusOK), responses[1]["status"])
+	require.Equal(t, float64(2), responses[1]["attempt"])
+	require.Equal(t, "req-1", responses[1]["request_id"])
+	require.Contains(t, responses[1], "latency")
+}
+
+func TestLoggerCanBeDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, sdk.WithLogger(nil))
+	require.NoError(t, err)
+
+	_, err = fb.GetSupportedAssets()
+	require.NoError(t, err)
+}
@@ -0,0 +1,406 @@
+package fireblocksdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Fireblocks Network endpoints: network connections and network IDs
+
+// ErrInvalidRoutingPolicy is returned before sending the request when the routing policy would be rejected
+var ErrInvalidRoutingPolicy = errors.New("invalid routing policy")
+
+// AssetClass is the key of the routing policy, every class is routed separately
+type AssetClass string
+
+const (
+	AssetClassCrypto     AssetClass = "crypto"
+	AssetClassSEN        AssetClass = "sen"
+	AssetClassSignet     AssetClass = "signet"
+	AssetClassSENTest    AssetClass = "sen_test"
+	AssetClassSignetTest AssetClass = "signet_test"
+)
+
+type RoutingScheme string
+
+const (
+	RoutingSchemeDefault RoutingScheme = "DEFAULT" // Routing policy of the network ID, only for network connections
+	RoutingSchemeCustom  RoutingScheme = "CUSTOM"  // Routing to DstType and DstID
+	RoutingSchemeNone    RoutingScheme = "NONE"    // The asset class is not accepted
+)
+
+type RoutingDestType string
+
+const (
+	RoutingDestTypeVault       RoutingDestType = "VAULT"
+	RoutingDestTypeFiatAccount RoutingDestType = "FIAT_ACCOUNT"
+)
+
+// RoutingPolicyEntry defines where incoming transfers of the asset class are deposited
+type RoutingPolicyEntry struct {
+	Scheme  RoutingScheme   `json:"scheme"`
+	DstType RoutingDestType `json:"dstType,omitempty"` // Only for CUSTOM scheme
+	DstID   string          `json:"dstId,omitempty"`   // Only for CUSTOM scheme, ID of the vault account or the fiat account
+}
+
+// RoutingPolicy defines model for NetworkConnectionRoutingPolicy, asset classes missing in the policy are not changed
+type RoutingPolicy map[AssetClass]*RoutingPolicyEntry
+
+// DefaultRouting uses the routing policy of the network ID
+func DefaultRouting() *RoutingPolicyEntry {
+	return &RoutingPolicyEntry{Scheme: RoutingSchemeDefault}
+}
+
+// NoRouting rejects incoming transfers of the asset class
+func NoRouting() *RoutingPolicyEntry {
+	return &RoutingPolicyEntry{Scheme: RoutingSchemeNone}
+}
+
+// RouteToVault deposits crypto to the vault account
+func RouteToVault(vaultAccountID string) *RoutingPolicyEntry {
+	return &RoutingPolicyEntry{Scheme: RoutingSchemeCustom, DstType: RoutingDestTypeVault, DstID: vaultAccountID}
+}
+
+// RouteToFiatAccount deposits SEN and Signet transfers to the fiat account
+func RouteToFiatAccount(fiatAccountID string) *RoutingPolicyEntry {
+	return &RoutingPolicyEntry{Scheme: RoutingSchemeCustom, DstType: RoutingDestTypeFiatAccount, DstID: fiatAccountID}
+}
+
+// ValidateForConnection checks the policy the way Fireblocks does for network connections
+func (p RoutingPolicy) ValidateForConnection() error {
+	return p.validate(true)
+}
+
+// ValidateForNetworkID checks the policy the way Fireblocks does for network IDs, which have no DEFAULT to fall back to
+func (p RoutingPolicy) ValidateForNetworkID() error {
+	return p.validate(false)
+}
+
+func (p RoutingPolicy) validate(allowDefault bool) error {
+	for class, entry := range p {
+		var dstType RoutingDestType
+
+		switch class {
+		case AssetClassCrypto:
+			dstType = RoutingDestTypeVault
+		case AssetClassSEN, AssetClassSignet, AssetClassSENTest, AssetClassSignetTest:
+			dstType = RoutingDestTypeFiatAccount
+		default:
+			return errors.Wrapf(ErrInvalidRoutingPolicy, "unknown asset class %q", class)
+		}
+
+		if entry == nil {
+			return errors.Wrapf(ErrInvalidRoutingPolicy, "%s has no routing", class)
+		}
+
+		switch entry.Scheme {
+		case RoutingSchemeCustom:
+			if entry.DstType != dstType {
+				return errors.Wrapf(ErrInvalidRoutingPolicy, "%s can be routed to %s only, got %q", class, dstType, entry.DstType)
+			}
+
+			if entry.DstID == "" {
+				return errors.Wrapf(ErrInvalidRoutingPolicy, "%s has no dstId", class)
+			}
+		case RoutingSchemeDefault, RoutingSchemeNone:
+			if entry.Scheme == RoutingSchemeDefault && !allowDefault {
+				return errors.Wrapf(ErrInvalidRoutingPolicy, "%s of network ID cannot use DEFAULT scheme", class)
+			}
+
+			if entry.DstType != "" || entry.DstID != "" {
+				return errors.Wrapf(ErrInvalidRoutingPolicy, "%s with %s scheme cannot have destination", class, entry.Scheme)
+			}
+		default:
+			return errors.Wrapf(ErrInvalidRoutingPolicy, "%s has unknown scheme %q", class, entry.Scheme)
+		}
+	}
+
+	return nil
+}
+
+// Responses
+
+// NetworkIDInfo identifies the network ID in the network connection
+type NetworkIDInfo struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// NetworkConnectionResponse defines model for NetworkConnection.
+type NetworkConnectionResponse struct {
+	ID              string        `json:"id,omitempty"`
+	LocalNetworkID  NetworkIDInfo `json:"localNetworkId"`
+	RemoteNetworkID NetworkIDInfo `json:"remoteNetworkId"`
+	RoutingPolicy   RoutingPolicy `json:"routingPolicy,omitempty"`
+	Status          string        `json:"status,omitempty"` // WAITING_FOR_APPROVAL | APPROVED | CANCELLED | REJECTED | FAILED etc.
+}
+
+// NetworkIDResponse defines model for NetworkId.
+type NetworkIDResponse struct {
+	ID             string        `json:"id,omitempty"`
+	Name           string        `json:"name,omitempty"`
+	IsDiscoverable bool          `json:"isDiscoverable"` // Whether other workspaces can find the network ID in the directory
+	RoutingPolicy  RoutingPolicy `json:"routingPolicy,omitempty"`
+}
+
+type ThirdPartyRoutingResponse struct {
+	IsThirdPartyRouting bool   `json:"isThirdPartyRouting"`
+	Description         string `json:"description,omitempty"`
+}
+
+// Requests
+
+type CreateNetworkConnectionRequest struct {
+	LocalNetworkID  string        `json:"localNetworkId"`
+	RemoteNetworkID string        `json:"remoteNetworkId"`
+	RoutingPolicy   RoutingPolicy `json:"routingPolicy,omitempty"`
+}
+
+type CreateNetworkIDRequest struct {
+	Name          string        `json:"name"`
+	RoutingPolicy RoutingPolicy `json:"routingPolicy,omitempty"`
+}
+
+type SetRoutingPolicyRequest struct {
+	RoutingPolicy RoutingPolicy `json:"routingPolicy"`
+}
+
+type SetDiscoverabilityRequest struct {
+	IsDiscoverable bool `json:"isDiscoverable"`
+}
+
+type SetNetworkIDNameRequest struct {
+	Name string `json:"name"`
+}
+
+// Network connections
+
+// GetNetworkConnections Gets all network connections of the workspace
+func (sdk *FireblocksSDK) GetNetworkConnections() (resp []*NetworkConnectionResponse, err error) {
+	return sdk.GetNetworkConnectionsWithContext(context.Background())
+}
+
+// GetNetworkConnectionsWithContext is GetNetworkConnections with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetNetworkConnectionsWithContext(ctx context.Context) (resp []*NetworkConnectionResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/network_connections", nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetNetworkConnectionByID Gets the network connection with its routing policy
+func (sdk *FireblocksSDK) GetNetworkConnectionByID(connectionID string) (resp *NetworkConnectionResponse, err error) {
+	return sdk.GetNetworkConnectionByIDWithContext(context.Background(), connectionID)
+}
+
+// GetNetworkConnectionByIDWithContext is GetNetworkConnectionByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetNetworkConnectionByIDWithContext(ctx context.Context, connectionID string) (resp *NetworkConnectionResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/network_connections/%s", url.PathEscape(connectionID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// CreateNetworkConnection Requests the connection of the local network ID to the remote one,
+// the routing policy is validated before sending
+func (sdk *FireblocksSDK) CreateNetworkConnection(req *CreateNetworkConnectionRequest, opts ...func(*PostRequestOption)) (resp *NetworkConnectionResponse, err error) {
+	return sdk.CreateNetworkConnectionWithContext(context.Background(), req, opts...)
+}
+
+// CreateNetworkConnectionWithContext is CreateNetworkConnection with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateNetworkConnectionWithContext(ctx context.Context, req *CreateNetworkConnectionRequest, opts ...func(*PostRequestOption)) (resp *NetworkConnectionResponse, err error) {
+	if req == nil {
+		return nil, errors.New("network connection request is required")
+	}
+
+	if req.LocalNetworkID == "" || req.RemoteNetworkID == "" {
+		return nil, errors.New("localNetworkId and remoteNetworkId are required")
+	}
+
+	if req.LocalNetworkID == req.RemoteNetworkID {
+		return nil, errors.New("network ID cannot be connected to itself")
+	}
+
+	if err := req.RoutingPolicy.ValidateForConnection(); err != nil {
+		return nil, err
+	}
+
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, "/network_connections", req, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// DeleteNetworkConnection Removes the network connection
+func (sdk *FireblocksSDK) DeleteNetworkConnection(connectionID string) error {
+	return sdk.DeleteNetworkConnectionWithContext(context.Background(), connectionID)
+}
+
+// DeleteNetworkConnectionWithContext is DeleteNetworkConnection with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteNetworkConnectionWithContext(ctx context.Context, connectionID string) error {
+	body, status, err := sdk.client.DoDeleteRequestWithContext(ctx, fmt.Sprintf("/network_connections/%s", url.PathEscape(connectionID)))
+
+	return handleResponse(body, status, err, &OperationSuccessResponse{}, http.StatusOK, http.StatusNoContent)
+}
+
+// SetNetworkConnectionRoutingPolicy Changes routing of the asset classes present in the policy
+func (sdk *FireblocksSDK) SetNetworkConnectionRoutingPolicy(connectionID string, policy RoutingPolicy) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetNetworkConnectionRoutingPolicyWithContext(context.Background(), connectionID, policy)
+}
+
+// SetNetworkConnectionRoutingPolicyWithContext is SetNetworkConnectionRoutingPolicy with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetNetworkConnectionRoutingPolicyWithContext(ctx context.Context, connectionID string, policy RoutingPolicy) (resp *OperationSuccessResponse, err error) {
+	if err := policy.ValidateForConnection(); err != nil {
+		return nil, err
+	}
+
+	body, status, err := sdk.client.DoPatchRequestWithContext(
+		ctx,
+		fmt.Sprintf("/network_connections/%s/set_routing_policy", url.PathEscape(connectionID)),
+		&SetRoutingPolicyRequest{RoutingPolicy: policy},
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// CheckThirdPartyRouting Reports whether transfers of the asset type over the connection are routed by a third party
+func (sdk *FireblocksSDK) CheckThirdPartyRouting(connectionID, assetType string) (resp *ThirdPartyRoutingResponse, err error) {
+	return sdk.CheckThirdPartyRoutingWithContext(context.Background(), connectionID, assetType)
+}
+
+// CheckThirdPartyRoutingWithContext is CheckThirdPartyRouting with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CheckThirdPartyRoutingWithContext(ctx context.Context, connectionID, assetType string) (resp *ThirdPartyRoutingResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(
+		ctx,
+		fmt.Sprintf("/network_connections/%s/is_third_party_routing/%s", url.PathEscape(connectionID), url.PathEscape(assetType)),
+		nil,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// Network IDs
+
+// GetNetworkIDs Gets the network IDs of the workspace
+func (sdk *FireblocksSDK) GetNetworkIDs() (resp []*NetworkIDResponse, err error) {
+	return sdk.GetNetworkIDsWithContext(context.Background())
+}
+
+// GetNetworkIDsWithContext is GetNetworkIDs with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetNetworkIDsWithContext(ctx context.Context) (resp []*NetworkIDResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/network_ids", nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetNetworkIDByID Gets the network ID with its routing policy
+func (sdk *FireblocksSDK) GetNetworkIDByID(networkID string) (resp *NetworkIDResponse, err error) {
+	return sdk.GetNetworkIDByIDWithContext(context.Background(), networkID)
+}
+
+// GetNetworkIDByIDWithContext is GetNetworkIDByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetNetworkIDByIDWithContext(ctx context.Context, networkID string) (resp *NetworkIDResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/network_ids/%s", url.PathEscape(networkID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// CreateNetworkID Creates the network ID, the routing policy is validated before sending
+func (sdk *FireblocksSDK) CreateNetworkID(name string, policy RoutingPolicy, opts ...func(*PostRequestOption)) (resp *NetworkIDResponse, err error) {
+	return sdk.CreateNetworkIDWithContext(context.Background(), name, policy, opts...)
+}
+
+// CreateNetworkIDWithContext is CreateNetworkID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateNetworkIDWithContext(ctx context.Context, name string, policy RoutingPolicy, opts ...func(*PostRequestOption)) (resp *NetworkIDResponse, err error) {
+	if name == "" {
+		return nil, errors.New("name of network ID is required")
+	}
+
+	if err := policy.ValidateForNetworkID(); err != nil {
+		return nil, err
+	}
+
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, "/network_ids", &CreateNetworkIDRequest{Name: name, RoutingPolicy: policy}, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// DeleteNetworkID Removes the network ID
+func (sdk *FireblocksSDK) DeleteNetworkID(networkID string) error {
+	return sdk.DeleteNetworkIDWithContext(context.Background(), networkID)
+}
+
+// DeleteNetworkIDWithContext is DeleteNetworkID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteNetworkIDWithContext(ctx context.Context, networkID string) error {
+	body, status, err := sdk.client.DoDeleteRequestWithContext(ctx, fmt.Sprintf("/network_ids/%s", url.PathEscape(networkID)))
+
+	return handleResponse(body, status, err, &OperationSuccessResponse{}, http.StatusOK, http.StatusNoContent)
+}
+
+// SetNetworkIDRoutingPolicy Changes routing of the asset classes present in the policy
+func (sdk *FireblocksSDK) SetNetworkIDRoutingPolicy(networkID string, policy RoutingPolicy) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetNetworkIDRoutingPolicyWithContext(context.Background(), networkID, policy)
+}
+
+// SetNetworkIDRoutingPolicyWithContext is SetNetworkIDRoutingPolicy with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetNetworkIDRoutingPolicyWithContext(ctx context.Context, networkID string, policy RoutingPolicy) (resp *OperationSuccessResponse, err error) {
+	if err := policy.ValidateForNetworkID(); err != nil {
+		return nil, err
+	}
+
+	body, status, err := sdk.client.DoPatchRequestWithContext(
+		ctx,
+		fmt.Sprintf("/network_ids/%s/set_routing_policy", url.PathEscape(networkID)),
+		&SetRoutingPolicyRequest{RoutingPolicy: policy},
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// SetNetworkIDDiscoverability Shows or hides the network ID in the Fireblocks Network directory
+func (sdk *FireblocksSDK) SetNetworkIDDiscoverability(networkID string, isDiscoverable bool) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetNetworkIDDiscoverabilityWithContext(context.Background(), networkID, isDiscoverable)
+}
+
+// SetNetworkIDDiscoverabilityWithContext is SetNetworkIDDiscoverability with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetNetworkIDDiscoverabilityWithContext(ctx context.Context, networkID string, isDiscoverable bool) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPatchRequestWithContext(
+		ctx,
+		fmt.Sprintf("/network_ids/%s/set_discoverability", url.PathEscape(networkID)),
+		&SetDiscoverabilityRequest{IsDiscoverable: isDiscoverable},
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// SetNetworkIDName Renames the network ID
+func (sdk *FireblocksSDK) SetNetworkIDName(networkID, name string) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetNetworkIDNameWithContext(context.Background(), networkID, name)
+}
+
+// SetNetworkIDNameWithContext is SetNetworkIDName with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetNetworkIDNameWithContext(ctx context.Context, networkID, name string) (resp *OperationSuccessResponse, err error) {
+	if name == "" {
+		return nil, errors.New("name of network ID is required")
+	}
+
+	body, status, err := sdk.client.DoPatchRequestWithContext(
+		ctx,
+		fmt.Sprintf("/network_ids/%s/set_name", url.PathEscape(networkID)),
+		&SetNetworkIDNameRequest{Name: name},
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
@@ -0,0 +1,186 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRoutingPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     sdk.RoutingPolicy
+		connection bool
+		networkID  bool
+	}{
+		{
+			name:       "custom crypto and fiat",
+			policy:     sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.RouteToVault("0"), sdk.AssetClassSEN: sdk.RouteToFiatAccount("f")},
+			connection: true,
+			networkID:  true,
+		},
+		{
+			name:       "default is only for connections",
+			policy:     sdk.RoutingPolicy{sdk.AssetClassSignet: sdk.DefaultRouting(), sdk.AssetClassSENTest: sdk.NoRouting()},
+			connection: true,
+		},
+		{
+			name:   "crypto to fiat account",
+			policy: sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.RouteToFiatAccount("f")},
+		},
+		{
+			name:   "SEN to vault",
+			policy: sdk.RoutingPolicy{sdk.AssetClassSEN: sdk.RouteToVault("0")},
+		},
+		{
+			name:   "custom without destination",
+			policy: sdk.RoutingPolicy{sdk.AssetClassCrypto: {Scheme: sdk.RoutingSchemeCustom, DstType: sdk.RoutingDestTypeVault}},
+		},
+		{
+			name:   "none with destination",
+			policy: sdk.RoutingPolicy{sdk.AssetClassCrypto: {Scheme: sdk.RoutingSchemeNone, DstType: sdk.RoutingDestTypeVault, DstID: "0"}},
+		},
+		{
+			name:   "unknown asset class",
+			policy: sdk.RoutingPolicy{"gold": sdk.NoRouting()},
+		},
+		{
+			name:   "unknown scheme",
+			policy: sdk.RoutingPolicy{sdk.AssetClassCrypto: {Scheme: "FIXED"}},
+		},
+		{
+			name:   "missing entry",
+			policy: sdk.RoutingPolicy{sdk.AssetClassCrypto: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.ValidateForConnection(); tt.connection {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, sdk.ErrInvalidRoutingPolicy)
+			}
+
+			if err := tt.policy.ValidateForNetworkID(); tt.networkID {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, sdk.ErrInvalidRoutingPolicy)
+			}
+		})
+	}
+}
+
+func TestNetworkSuite(t *testing.T) {
+	suite.Run(t, new(NetworkSuite))
+}
+
+type NetworkSuite struct {
+	suite.Suite
+	srv *fireblockstest.Server
+	sdk *sdk.FireblocksSDK
+}
+
+func (suite *NetworkSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+}
+
+func (suite *NetworkSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *NetworkSuite) TestNetworkIDs() {
+	created, err := suite.sdk.CreateNetworkID("treasury", sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.RouteToVault("0")})
+	require.NoError(suite.T(), err)
+	require.False(suite.T(), created.IsDiscoverable)
+
+	_, err = suite.sdk.SetNetworkIDDiscoverability(created.ID, true)
+	require.NoError(suite.T(), err)
+
+	_, err = suite.sdk.SetNetworkIDName(created.ID, "settlement")
+	require.NoError(suite.T(), err)
+
+	_, err = suite.sdk.SetNetworkIDRoutingPolicy(created.ID, sdk.RoutingPolicy{sdk.AssetClassSEN: sdk.RouteToFiatAccount("f")})
+	require.NoError(suite.T(), err)
+
+	networkID, err := suite.sdk.GetNetworkIDByID(created.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "settlement", networkID.Name)
+	require.True(suite.T(), networkID.IsDiscoverable)
+	require.Equal(suite.T(), sdk.RoutingPolicy{
+		sdk.AssetClassCrypto: sdk.RouteToVault("0"),
+		sdk.AssetClassSEN:    sdk.RouteToFiatAccount("f"),
+	}, networkID.RoutingPolicy)
+
+	networkIDs, err := suite.sdk.GetNetworkIDs()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), networkIDs, 1)
+
+	require.NoError(suite.T(), suite.sdk.DeleteNetworkID(created.ID))
+
+	_, err = suite.sdk.GetNetworkIDByID(created.ID)
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *NetworkSuite) TestNetworkConnections() {
+	local, err := suite.sdk.CreateNetworkID("treasury", nil)
+	require.NoError(suite.T(), err)
+
+	remote := suite.srv.AddNetworkID("counterparty", true)
+
+	connection, err := suite.sdk.CreateNetworkConnection(&sdk.CreateNetworkConnectionRequest{
+		LocalNetworkID:  local.ID,
+		RemoteNetworkID: remote,
+		RoutingPolicy:   sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.DefaultRouting()},
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "counterparty", connection.RemoteNetworkID.Name)
+
+	_, err = suite.sdk.SetNetworkConnectionRoutingPolicy(connection.ID, sdk.RoutingPolicy{sdk.AssetClassSignet: sdk.NoRouting()})
+	require.NoError(suite.T(), err)
+
+	got, err := suite.sdk.GetNetworkConnectionByID(connection.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.RoutingPolicy{
+		sdk.AssetClassCrypto: sdk.DefaultRouting(),
+		sdk.AssetClassSignet: sdk.NoRouting(),
+	}, got.RoutingPolicy)
+
+	routing, err := suite.sdk.CheckThirdPartyRouting(connection.ID, "CRYPTO")
+	require.NoError(suite.T(), err)
+	require.False(suite.T(), routing.IsThirdPartyRouting)
+
+	connections, err := suite.sdk.GetNetworkConnections()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), connections, 1)
+
+	require.NoError(suite.T(), suite.sdk.DeleteNetworkConnection(connection.ID))
+	require.ErrorIs(suite.T(), suite.sdk.DeleteNetworkConnection(connection.ID), sdk.ErrNotFound)
+}
+
+func (suite *NetworkSuite) TestInvalidPolicyIsNotSent() {
+	_, err := suite.sdk.CreateNetworkID("treasury", sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.DefaultRouting()})
+	require.ErrorIs(suite.T(), err, sdk.ErrInvalidRoutingPolicy)
+
+	_, err = suite.sdk.SetNetworkIDRoutingPolicy("1", sdk.RoutingPolicy{sdk.AssetClassSEN: sdk.RouteToVault("0")})
+	require.ErrorIs(suite.T(), err, sdk.ErrInvalidRoutingPolicy)
+
+	_, err = suite.sdk.SetNetworkConnectionRoutingPolicy("1", sdk.RoutingPolicy{sdk.AssetClassCrypto: sdk.RouteToFiatAccount("f")})
+	require.ErrorIs(suite.T(), err, sdk.ErrInvalidRoutingPolicy)
+
+	_, err = suite.sdk.CreateNetworkConnection(&sdk.CreateNetworkConnectionRequest{LocalNetworkID: "1", RemoteNetworkID: "1"})
+	require.Error(suite.T(), err)
+
+	_, err = suite.sdk.CreateNetworkConnection(nil)
+	require.Error(suite.T(), err)
+
+	require.Empty(suite.T(), suite.srv.Requests())
+}
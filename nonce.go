@@ -0,0 +1,54 @@
+package fireblocksdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// INonceProvider gives the nonce claim, every call must return the value never returned before
+type INonceProvider interface {
+	Nonce() string
+}
+
+func DefaultNonceProvider() INonceProvider {
+	return &RandomNonceProvider{}
+}
+
+// RandomNonceProvider returns 128 random bits encoded as hex
+type RandomNonceProvider struct {
+	fallback MonotonicNonceProvider
+}
+
+func (np *RandomNonceProvider) Nonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// claims cannot fail, the counter is still unique within the process
+		return np.fallback.Nonce()
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// MonotonicNonceProvider returns increasing numbers which start at the time of the first call in nanoseconds,
+// so the nonces stay unique after restart of the process
+type MonotonicNonceProvider struct {
+	last int64
+}
+
+func (np *MonotonicNonceProvider) Nonce() string {
+	for {
+		last := atomic.LoadInt64(&np.last)
+
+		next := time.Now().UnixNano()
+		if next <= last {
+			next = last + 1
+		}
+
+		if atomic.CompareAndSwapInt64(&np.last, last, next) {
+			return strconv.FormatInt(next, 10)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package fireblocksdk
+
+import (
+	"context"
+)
+
+// PageFetcher loads the page which starts at cursor and returns cursor of the next page,
+// empty next cursor means the page is the last one
+type PageFetcher[T any] func(ctx context.Context, cursor string) (items []T, next string, err error)
+
+// Pager iterates over all items of a paged endpoint, following the cursors page by page
+//
+//	pager := fb.NewVaultAccountsPager(&PagedVaultAccountsRequestFilters{Limit: 100})
+//	for pager.Next(ctx) {
+//		account := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//	}
+type Pager[T any] struct {
+	fetch   PageFetcher[T]
+	cursor  string
+	page    []T
+	index   int
+	started bool
+	done    bool
+	err     error
+}
+
+// NewPager Creates pager which starts at cursor, empty cursor is the first page
+func NewPager[T any](cursor string, fetch PageFetcher[T]) *Pager[T] {
+	return &Pager[T]{fetch: fetch, cursor: cursor, index: -1}
+}
+
+// Next advances to the next item, the next page is loaded when the current one is exhausted.
+// It returns false when there are no more items or the page failed to load, see Err.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.index++
+
+	for p.index >= len(p.page) {
+		if p.done {
+			return false
+		}
+
+		if !p.nextPage(ctx) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Pager[T]) nextPage(ctx context.Context) bool {
+	if p.started && p.cursor == "" {
+		p.done = true
+		return false
+	}
+
+	items, next, err := p.fetch(ctx, p.cursor)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.started = true
+	p.page = items
+	p.index = 0
+
+	// the same cursor returned twice would loop forever
+	if next == p.cursor {
+		next = ""
+	}
+
+	p.cursor = next
+	if next == "" {
+		p.done = true
+	}
+
+	return true
+}
+
+// Item returns the current item, valid after Next returned true
+func (p *Pager[T]) Item() T {
+	return p.page[p.index]
+}
+
+// Err returns error which stopped iteration
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All loads the remaining items of all pages
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+
+	for p.Next(ctx) {
+		items = append(items, p.Item())
+	}
+
+	return items, p.Err()
+}
@@ -0,0 +1,113 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	"encoding/json"
+	sdk "fireblocksdk"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestPagerSuite(t *testing.T) {
+	suite.Run(t, new(PagerSuite))
+}
+
+type PagerSuite struct {
+	suite.Suite
+}
+
+func (suite *PagerSuite) TestFollowsCursorsUntilLastPage() {
+	pages := map[string][]int{"": {1, 2}, "a": {3}, "b": {}, "c": {4, 5}}
+	next := map[string]string{"": "a", "a": "b", "b": "c", "c": ""}
+
+	var cursors []string
+
+	pager := sdk.NewPager("", func(ctx context.Context, cursor string) ([]int, string, error) {
+		cursors = append(cursors, cursor)
+		return pages[cursor], next[cursor], nil
+	})
+
+	items, err := pager.All(context.Background())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []int{1, 2, 3, 4, 5}, items)
+	require.Equal(suite.T(), []string{"", "a", "b", "c"}, cursors)
+	require.False(suite.T(), pager.Next(context.Background()))
+}
+
+func (suite *PagerSuite) TestStopsOnError() {
+	failure := errors.New("failure")
+
+	pager := sdk.NewPager("", func(ctx context.Context, cursor string) ([]int, string, error) {
+		if cursor == "" {
+			return []int{1}, "a", nil
+		}
+
+		return nil, "", failure
+	})
+
+	require.True(suite.T(), pager.Next(context.Background()))
+	require.Equal(suite.T(), 1, pager.Item())
+	require.False(suite.T(), pager.Next(context.Background()))
+	require.ErrorIs(suite.T(), pager.Err(), failure)
+	require.False(suite.T(), pager.Next(context.Background()))
+}
+
+func (suite *PagerSuite) TestStopsWhenCursorRepeats() {
+	calls := 0
+
+	pager := sdk.NewPager("a", func(ctx context.Context, cursor string) ([]int, string, error) {
+		calls++
+		return []int{calls}, "a", nil
+	})
+
+	items, err := pager.All(context.Background())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []int{1}, items)
+}
+
+func (suite *PagerSuite) TestVaultAccountsPager() {
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		after := r.URL.Query().Get("after")
+		resp := sdk.PagedVaultAccountsResponse{}
+
+		switch after {
+		case "":
+			resp.Accounts = []sdk.VaultAccountResponse{{ID: "1"}, {ID: "2"}}
+			resp.Paging.After = "cursor-2"
+		case "cursor-2":
+			resp.Accounts = []sdk.VaultAccountResponse{{ID: "3"}}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL)
+	require.NoError(suite.T(), err)
+
+	pager := fb.NewVaultAccountsPager(&sdk.PagedVaultAccountsRequestFilters{Limit: 2, NamePrefix: "vault"})
+
+	var ids []string
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.Item().ID)
+	}
+
+	require.NoError(suite.T(), pager.Err())
+	require.Equal(suite.T(), []string{"1", "2", "3"}, ids)
+	require.Equal(suite.T(), []string{
+		"limit=2&namePrefix=vault",
+		"after=cursor-2&limit=2&namePrefix=vault",
+	}, queries)
+}
@@ -21,7 +21,11 @@ func (e QueryItem) String() string {
 // BuildQuery uses `env` and `envDefault` as tag to bind config to viper bindings
 // Example: `env:"USERNAME" envDefault:"admin"`
 func BuildQuery(in any) QueryItems {
-	if in == nil || reflect.ValueOf(in).IsNil() {
+	if in == nil {
+		return nil
+	}
+
+	if v := reflect.ValueOf(in); v.Kind() == reflect.Ptr && v.IsNil() {
 		return nil
 	}
 
@@ -70,8 +74,17 @@ func iterateStructFields(t reflect.Type, v any, vars *QueryItems) {
 		value := val.Field(i)
 		kind := value.Kind()
 
+		// omitempty fields are skipped the same way as encoding/json does, so zero limits are not sent
+		if value.IsZero() && strings.Contains(tag, "omitempty") {
+			continue
+		}
+
 		var vv any
 		if kind == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+
 			value = value.Elem()
 			vv = value.Interface()
 		} else {
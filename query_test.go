@@ -89,3 +89,20 @@ func (suite *QuerySuite) TestMarshal() {
 	err = json.Unmarshal(body, target)
 	require.NoError(suite.T(), err)
 }
+
+type OmitEmptyQueryStruct struct {
+	Limit  int64   `json:"limit,omitempty"`
+	Status string  `json:"status,omitempty"`
+	After  *string `json:"after"`
+	Sort   string  `json:"sort"`
+}
+
+func (suite *QuerySuite) TestOmitEmptySkipsZeroValues() {
+	values := sdk.BuildQuery(&OmitEmptyQueryStruct{Status: "COMPLETED"})
+	require.Equal(suite.T(), 2, len(values))
+
+	query := values.URLValues()
+	require.Equal(suite.T(), "COMPLETED", query.Get("status"))
+	require.False(suite.T(), query.Has("limit"))
+	require.False(suite.T(), query.Has("after"))
+}
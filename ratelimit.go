@@ -0,0 +1,136 @@
+package fireblocksdk
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointClass groups endpoints which share the client side rate limit
+type EndpointClass string
+
+const (
+	EndpointClassRead         EndpointClass = "read"         // GET requests
+	EndpointClassWrite        EndpointClass = "write"        // POST, PUT and DELETE requests
+	EndpointClassTransactions EndpointClass = "transactions" // POST /transactions, creation of new transactions
+)
+
+// EndpointClassifier assigns the endpoint class to the request, path is the full URL path
+type EndpointClassifier func(method, path string) EndpointClass
+
+// DefaultEndpointClassifier separates reads, writes and creation of transactions
+func DefaultEndpointClassifier(method, path string) EndpointClass {
+	switch {
+	case method == http.MethodGet:
+		return EndpointClassRead
+	case method == http.MethodPost && strings.HasSuffix(strings.TrimSuffix(path, "/"), "/transactions"):
+		return EndpointClassTransactions
+	default:
+		return EndpointClassWrite
+	}
+}
+
+// RateLimit allows Rate requests per second on average and up to Burst requests at once
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// TokenBucket is the rate limiter shared by all requests of the endpoint class
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket creates the bucket which is full, burst below 1 is treated as 1
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until the token is available or ctx is done
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+
+	now := b.now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	// the token is taken in advance, so concurrent callers queue up one after another
+	b.tokens--
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+
+	b.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport waits for the token before every attempt, including retries
+type rateLimitTransport struct {
+	next     http.RoundTripper
+	classify EndpointClassifier
+	buckets  map[EndpointClass]*TokenBucket
+}
+
+func newRateLimitTransport(next http.RoundTripper, classify EndpointClassifier, limits map[EndpointClass]RateLimit) *rateLimitTransport {
+	if classify == nil {
+		classify = DefaultEndpointClassifier
+	}
+
+	buckets := make(map[EndpointClass]*TokenBucket, len(limits))
+	for class, limit := range limits {
+		buckets[class] = NewTokenBucket(limit.Rate, limit.Burst)
+	}
+
+	return &rateLimitTransport{next: next, classify: classify, buckets: buckets}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if bucket, ok := t.buckets[t.classify(req.Method, req.URL.Path)]; ok {
+		if err := bucket.Wait(req.Context()); err != nil {
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,94 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultEndpointClassifier(t *testing.T) {
+	require.Equal(t, sdk.EndpointClassRead, sdk.DefaultEndpointClassifier(http.MethodGet, "/v1/transactions"))
+	require.Equal(t, sdk.EndpointClassTransactions, sdk.DefaultEndpointClassifier(http.MethodPost, "/v1/transactions"))
+	require.Equal(t, sdk.EndpointClassWrite, sdk.DefaultEndpointClassifier(http.MethodPost, "/v1/transactions/1/cancel"))
+	require.Equal(t, sdk.EndpointClassWrite, sdk.DefaultEndpointClassifier(http.MethodPut, "/v1/vault/accounts/1"))
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := sdk.NewTokenBucket(50, 2)
+
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, bucket.Wait(context.Background()))
+	}
+
+	// burst of 2 passes at once, other 2 tokens are refilled in 20ms each
+	require.GreaterOrEqual(t, time.Since(started), 35*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	slow := sdk.NewTokenBucket(0.1, 1)
+	require.NoError(t, slow.Wait(ctx))
+	require.ErrorIs(t, slow.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimitPerEndpointClass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, sdk.WithRateLimit(sdk.EndpointClassRead, 20, 1))
+	require.NoError(t, err)
+
+	started := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err = fb.GetSupportedAssets()
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(started), 190*time.Millisecond)
+
+	started = time.Now()
+	for i := 0; i < 5; i++ {
+		_, err = fb.CreateVaultAccount("name", "", nil, nil)
+		require.NoError(t, err)
+	}
+	require.Less(t, time.Since(started), 100*time.Millisecond, "writes are not limited")
+}
+
+func TestTokenIsSignedAfterRateLimitWait(t *testing.T) {
+	srv := fireblockstest.NewServer()
+	defer srv.Close()
+
+	// the last call waits 3s for the limit, longer than the token lives
+	fb, err := sdk.CreateSDK(srv.APIKey, srv.PrivateKeyPEM(), srv.URL,
+		sdk.WithTokenTimeout(1),
+		sdk.WithRateLimit(sdk.EndpointClassRead, 1, 1),
+	)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := fb.GetSupportedAssets()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,70 @@
+package fireblocksdk
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy is the default CheckRetry of APIClient. It retries connection errors, 429 and 5xx responses
+// the same way as retryablehttp.DefaultRetryPolicy. Every method is retried: GET, PUT, PATCH and DELETE
+// of Fireblocks are idempotent and APIClient sends every POST with Idempotency-Key, the one given by
+// WithIdempotencyKey or a generated one, so the retried POST is not applied twice.
+func RetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	// the signer fails the same way on the next attempt
+	var signErr *signingError
+	if errors.As(err, &signErr) {
+		return false, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// RetryAfterBackoff is the default Backoff of APIClient. It waits as long as Retry-After of 429 and 503
+// responses asks, up to max, both delay-seconds and HTTP-date forms are accepted, otherwise it backs off exponentially.
+func RetryAfterBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if wait > max {
+				wait = max
+			}
+
+			return wait
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := at.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
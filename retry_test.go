@@ -0,0 +1,118 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostWithoutCallerKeyIsRetried(t *testing.T) {
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	noWait := func(time.Duration, time.Duration, int, *http.Response) time.Duration { return 0 }
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, sdk.WithRetryPolicy(nil, noWait))
+	require.NoError(t, err)
+
+	_, err = fb.CreateVaultAccount("name", "", nil, nil)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.NotEmpty(t, keys[0])
+	require.Equal(t, keys[0], keys[1], "the retry is deduplicated by the generated key")
+}
+
+func TestRetryPolicyStopsOnContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	retry, err := sdk.RetryPolicy(ctx, nil, errors.New("connection reset"))
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, retry)
+}
+
+func TestRetryAfterBackoff(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+
+	resp.Header.Set("Retry-After", "7")
+	require.Equal(t, 7*time.Second, sdk.RetryAfterBackoff(time.Second, 30*time.Second, 0, resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(20*time.Second).UTC().Format(http.TimeFormat))
+	wait := sdk.RetryAfterBackoff(time.Second, 30*time.Second, 0, resp)
+	require.InDelta(t, 20*time.Second, wait, float64(2*time.Second))
+
+	resp.Header.Set("Retry-After", "120")
+	require.Equal(t, 30*time.Second, sdk.RetryAfterBackoff(time.Second, 30*time.Second, 0, resp), "capped at max")
+
+	resp.Header.Set("Retry-After", "soon")
+	require.Equal(t, 4*time.Second, sdk.RetryAfterBackoff(time.Second, 30*time.Second, 2, resp))
+
+	resp.StatusCode = http.StatusInternalServerError
+	resp.Header.Set("Retry-After", "7")
+	require.Equal(t, time.Second, sdk.RetryAfterBackoff(time.Second, 30*time.Second, 0, resp))
+}
+
+func TestRetriesHonorRetryAfter(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL)
+	require.NoError(t, err)
+
+	started := time.Now()
+	_, err = fb.GetSupportedAssets()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(started), time.Second)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimitedErrorKeepsRetryAfter(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	noWait := func(time.Duration, time.Duration, int, *http.Response) time.Duration { return 0 }
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, sdk.WithRetryPolicy(nil, noWait))
+	require.NoError(t, err)
+
+	_, err = fb.GetSupportedAssets()
+	require.ErrorIs(t, err, sdk.ErrRateLimited)
+
+	var apiErr *sdk.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, 30*time.Second, apiErr.RetryAfter)
+	require.Equal(t, int32(5), atomic.LoadInt32(&calls), "first attempt and 4 retries")
+}
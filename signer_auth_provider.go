@@ -0,0 +1,101 @@
+package fireblocksdk
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"io"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+// SignerAuthProvider signs tokens with crypto.Signer, so the API secret key can stay
+// in PKCS#11 token, cloud KMS or remote signing agent and never be loaded in process memory.
+type SignerAuthProvider struct {
+	apiKey        string
+	signer        crypto.Signer
+	claimProvider IFireblocksClaims
+}
+
+// NewSignerAuthProvider Creates signer using api key and crypto.Signer holding RSA private key
+func NewSignerAuthProvider(apiKey string, signer crypto.Signer, configs ...func(*AuthProviderConfig) error) (*SignerAuthProvider, error) {
+	cfg := &AuthProviderConfig{DefaultTimeProvider(), DefaultNonceProvider(), DefaultTokenExpiry()}
+	for _, conf := range configs {
+		err := conf(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid/unsupported options")
+		}
+	}
+
+	if signer == nil {
+		return nil, errors.New("signer is required")
+	}
+
+	// Fireblocks accepts RS256 tokens only
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return nil, errors.Wrapf(jwt.ErrInvalidKeyType, "signer must hold RSA key, got %T", signer.Public())
+	}
+
+	auth := &SignerAuthProvider{
+		apiKey,
+		signer,
+		NewFireblocksClaimsProviderWithNonce(cfg.timeProvider, cfg.nonceProvider, cfg.expirySeconds),
+	}
+
+	return auth, nil
+}
+
+// SignJwt Creates token using path and payload
+func (sp *SignerAuthProvider) SignJwt(path string, bodyJSON []byte) (string, error) {
+	hash, err := hashBody(bodyJSON)
+	if err != nil {
+		return "", err
+	}
+
+	claims := sp.claimProvider.CreateClaims(path, sp.apiKey, hash)
+
+	signingString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SigningString()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create token")
+	}
+
+	digest := sha256.Sum256([]byte(signingString))
+
+	signature, err := sp.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign token")
+	}
+
+	return signingString + "." + jwt.EncodeSegment(signature), nil
+}
+
+func (sp *SignerAuthProvider) GetAPIKey() string {
+	return sp.apiKey
+}
+
+// SoftwareSigner is the reference crypto.Signer backed by RSA private key in memory.
+// It is meant for tests and as a template for HSM and KMS backed implementations.
+type SoftwareSigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewSoftwareSigner Creates crypto.Signer from PEM encoded RSA private key
+func NewSoftwareSigner(apiSecretKey []byte) (*SoftwareSigner, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(apiSecretKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read token from string")
+	}
+
+	return &SoftwareSigner{key}, nil
+}
+
+func (s *SoftwareSigner) Public() crypto.PublicKey {
+	return &s.key.PublicKey
+}
+
+// Sign signs digest with PKCS #1 v1.5, the padding used by RS256
+func (s *SoftwareSigner) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return rsa.SignPKCS1v15(random, s.key, opts.HashFunc(), digest)
+}
@@ -0,0 +1,133 @@
+package fireblocksdk_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	sdk "fireblocksdk"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSignerAuthProviderSuite(t *testing.T) {
+	suite.Run(t, new(SignerAuthProviderSuite))
+}
+
+type SignerAuthProviderSuite struct {
+	suite.Suite
+	reference    sdk.IAuthProvider
+	auth         sdk.IAuthProvider
+	signer       *sdk.SoftwareSigner
+	timeProvider sdk.ITimeProvider
+}
+
+func (suite *SignerAuthProviderSuite) SetupTest() {
+	suite.timeProvider = &testTimeProvider{}
+
+	reference, err := sdk.NewAuthProvider("apiKey", []byte(privateKey),
+		sdk.WithTimeProvider(suite.timeProvider),
+		sdk.WithNonceProvider(&testNonceProvider{}),
+	)
+	require.NoError(suite.T(), err)
+
+	signer, err := sdk.NewSoftwareSigner([]byte(privateKey))
+	require.NoError(suite.T(), err)
+
+	auth, err := sdk.NewSignerAuthProvider("apiKey", signer,
+		sdk.WithTimeProvider(suite.timeProvider),
+		sdk.WithNonceProvider(&testNonceProvider{}),
+	)
+	require.NoError(suite.T(), err)
+
+	suite.reference = reference
+	suite.signer = signer
+	suite.auth = auth
+}
+
+func (suite *SignerAuthProviderSuite) TestTokenMatchesAuthProvider() {
+	for _, body := range []string{"", `{"name":"vault"}`} {
+		expected, err := suite.reference.SignJwt("/v1/vault/accounts", []byte(body))
+		require.NoError(suite.T(), err)
+
+		actual, err := suite.auth.SignJwt("/v1/vault/accounts", []byte(body))
+		require.NoError(suite.T(), err)
+
+		require.Equal(suite.T(), expected, actual)
+	}
+}
+
+func (suite *SignerAuthProviderSuite) TestTokenIsVerifiedWithPublicKey() {
+	token, err := suite.auth.SignJwt("/v1/supported_assets", nil)
+	require.NoError(suite.T(), err)
+
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	parsed, err := parser.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		return suite.signer.Public(), nil
+	})
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), parsed.Valid)
+	require.Equal(suite.T(), "RS256", parsed.Method.Alg())
+}
+
+func (suite *SignerAuthProviderSuite) TestGetAPIKey() {
+	require.Equal(suite.T(), "apiKey", suite.auth.GetAPIKey())
+}
+
+func (suite *SignerAuthProviderSuite) TestMustFailWithNotRSASigner() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(suite.T(), err)
+
+	_, err = sdk.NewSignerAuthProvider("apiKey", key)
+	require.Error(suite.T(), err)
+	require.ErrorIs(suite.T(), err, jwt.ErrInvalidKeyType)
+}
+
+func (suite *SignerAuthProviderSuite) TestSignerErrorIsReturned() {
+	auth, err := sdk.NewSignerAuthProvider("apiKey", &failingSigner{suite.signer})
+	require.NoError(suite.T(), err)
+
+	_, err = auth.SignJwt("/v1/supported_assets", nil)
+	require.ErrorIs(suite.T(), err, errSignerUnavailable)
+}
+
+func (suite *SignerAuthProviderSuite) TestSignerErrorIsNotRetried() {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	auth, err := sdk.NewSignerAuthProvider("apiKey", &failingSigner{suite.signer})
+	require.NoError(suite.T(), err)
+
+	fb, err := sdk.CreateSDK("apiKey", nil, server.URL, sdk.WithAuthProvider(auth))
+	require.NoError(suite.T(), err)
+
+	started := time.Now()
+	_, err = fb.GetSupportedAssets()
+	require.ErrorIs(suite.T(), err, errSignerUnavailable)
+	require.Less(suite.T(), time.Since(started), time.Second, "signing must not be retried")
+	require.Zero(suite.T(), atomic.LoadInt32(&calls))
+}
+
+var errSignerUnavailable = errors.New("signer unavailable")
+
+// failingSigner imitates HSM which is not reachable
+type failingSigner struct {
+	*sdk.SoftwareSigner
+}
+
+func (s *failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errSignerUnavailable
+}
@@ -0,0 +1,200 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const emptyBodyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestSigningSuite(t *testing.T) {
+	suite.Run(t, new(SigningSuite))
+}
+
+// signedRequest is what the server received together with claims of the token
+type signedRequest struct {
+	method     string
+	requestURI string
+	body       string
+	claims     jwt.MapClaims
+}
+
+type SigningSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	client   *sdk.APIClient
+	received signedRequest
+}
+
+func (suite *SigningSuite) SetupTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		claims := jwt.MapClaims{}
+		token := r.Header.Get("Authorization")[len("Bearer "):]
+		_, _, err := new(jwt.Parser).ParseUnverified(token, claims)
+		suite.NoError(err)
+
+		suite.received = signedRequest{r.Method, r.URL.RequestURI(), string(body), claims}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey),
+		sdk.WithTimeProvider(&testTimeProvider{}),
+		sdk.WithNonceProvider(&testNonceProvider{}),
+	)
+	require.NoError(suite.T(), err)
+
+	suite.client = sdk.NewAPIClient(auth, suite.server.URL)
+}
+
+func (suite *SigningSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *SigningSuite) requireClaims(method, uri, body, bodyHash string) {
+	require.Equal(suite.T(), method, suite.received.method)
+	require.Equal(suite.T(), uri, suite.received.requestURI)
+	require.Equal(suite.T(), body, suite.received.body)
+	require.Equal(suite.T(), jwt.MapClaims{
+		"uri":      uri,
+		"nonce":    "nonce",
+		"iat":      float64(1000),
+		"now":      float64(1000),
+		"exp":      float64(1010),
+		"sub":      "apiKey",
+		"bodyHash": bodyHash,
+	}, suite.received.claims)
+}
+
+func (suite *SigningSuite) TestGet() {
+	_, _, err := suite.client.DoGetRequestWithContext(context.Background(), "/vault/accounts", url.Values{
+		"namePrefix": {"Test"},
+		"assetId":    {"BTC_TEST"},
+	})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(http.MethodGet, "/v1/vault/accounts?assetId=BTC_TEST&namePrefix=Test", "", emptyBodyHash)
+}
+
+func (suite *SigningSuite) TestGetWithoutQuery() {
+	_, _, err := suite.client.DoGetRequest("/supported_assets", url.Values{})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(http.MethodGet, "/v1/supported_assets", "", emptyBodyHash)
+}
+
+func (suite *SigningSuite) TestGetWithSpecialCharacters() {
+	_, _, err := suite.client.DoGetRequest("/vault/accounts", url.Values{
+		"namePrefix": {"my vault+1"},
+		"nameSuffix": {"a&b=c/ü?#%"},
+	})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(
+		http.MethodGet,
+		"/v1/vault/accounts?namePrefix=my%20vault%2B1&nameSuffix=a%26b%3Dc%2F%C3%BC%3F%23%25",
+		"",
+		emptyBodyHash,
+	)
+}
+
+func (suite *SigningSuite) TestPost() {
+	_, _, err := suite.client.DoPostRequest("/vault/accounts", map[string]string{"name": "vault"})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(
+		http.MethodPost,
+		"/v1/vault/accounts",
+		`{"name":"vault"}`,
+		"649f11a7754d375da975297137dfbaa8fc4b390c91b8828ac496ac522b2663da",
+	)
+}
+
+func (suite *SigningSuite) TestPostEmptyObject() {
+	_, _, err := suite.client.DoPostRequest("/transactions/1/cancel", map[string]string{})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(http.MethodPost, "/v1/transactions/1/cancel", "", emptyBodyHash)
+}
+
+func (suite *SigningSuite) TestPut() {
+	_, _, err := suite.client.DoPutRequest("/vault/accounts/1", map[string]string{"name": "renamed"})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(
+		http.MethodPut,
+		"/v1/vault/accounts/1",
+		`{"name":"renamed"}`,
+		"5480d74271a1634fc3f5859bc1808f3f7a92535735f76f5c753f41ba5e4dea79",
+	)
+}
+
+func (suite *SigningSuite) TestPatch() {
+	_, _, err := suite.client.DoPatchRequest("/network_ids/1/set_name", map[string]string{"name": "renamed"})
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(
+		http.MethodPatch,
+		"/v1/network_ids/1/set_name",
+		`{"name":"renamed"}`,
+		"5480d74271a1634fc3f5859bc1808f3f7a92535735f76f5c753f41ba5e4dea79",
+	)
+}
+
+func (suite *SigningSuite) TestDelete() {
+	_, _, err := suite.client.DoDeleteRequest("/internal_wallets/1")
+	require.NoError(suite.T(), err)
+
+	suite.requireClaims(http.MethodDelete, "/v1/internal_wallets/1", "", emptyBodyHash)
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	require.Equal(t, "", sdk.CanonicalQuery(nil))
+	require.Equal(t, "a=1&a=2&b=x%20y&c=%2B", sdk.CanonicalQuery(url.Values{"c": {"+"}, "b": {"x y"}, "a": {"1", "2"}}))
+}
+
+func TestRetryIsSignedAgain(t *testing.T) {
+	var claims []jwt.MapClaims
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := jwt.MapClaims{}
+		_, _, err := new(jwt.Parser).ParseUnverified(r.Header.Get("Authorization")[len("Bearer "):], c)
+		require.NoError(t, err)
+
+		claims = append(claims, c)
+		if len(claims) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey))
+	require.NoError(t, err)
+
+	client := sdk.NewAPIClient(auth, server.URL, func(c *sdk.APIClientConfig) {
+		c.RetryWaitMin = time.Millisecond
+		c.RetryWaitMax = time.Millisecond
+	})
+
+	_, _, err = client.DoPostRequest("/vault/accounts", map[string]string{"name": "vault"})
+	require.NoError(t, err)
+
+	require.Len(t, claims, 2)
+	require.NotEqual(t, claims[0]["nonce"], claims[1]["nonce"], "every attempt needs the new nonce")
+	require.Equal(t, claims[0]["uri"], claims[1]["uri"])
+	require.Equal(t, claims[0]["bodyHash"], claims[1]["bodyHash"])
+}
@@ -0,0 +1,262 @@
+package fireblocksdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "fireblocksdk"
+
+var (
+	numericSegment = regexp.MustCompile(`^[0-9]+$`)
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	assetSegment   = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// EndpointTemplate replaces IDs in the path with placeholders, so metrics are grouped by endpoint:
+// /vault/accounts/12/BTC_TEST becomes /vault/accounts/{id}/{assetId}
+func EndpointTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case i > 0 && segments[i-1] == "external_tx_id":
+			segments[i] = "{externalTxId}"
+		case numericSegment.MatchString(segment), uuidSegment.MatchString(segment):
+			segments[i] = "{id}"
+		case assetSegment.MatchString(segment):
+			segments[i] = "{assetId}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+type InstrumentationConfig struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	endpoint       func(path string) string
+}
+
+// WithInstrumentationTracerProvider sets the provider of the spans, the global provider is used otherwise
+func WithInstrumentationTracerProvider(provider trace.TracerProvider) func(*InstrumentationConfig) {
+	return func(c *InstrumentationConfig) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithInstrumentationMeterProvider sets the provider of the metrics, the global provider is used otherwise
+func WithInstrumentationMeterProvider(provider metric.MeterProvider) func(*InstrumentationConfig) {
+	return func(c *InstrumentationConfig) {
+		c.meterProvider = provider
+	}
+}
+
+// WithEndpointTemplate replaces EndpointTemplate used for span names and metric attributes
+func WithEndpointTemplate(endpoint func(path string) string) func(*InstrumentationConfig) {
+	return func(c *InstrumentationConfig) {
+		c.endpoint = endpoint
+	}
+}
+
+// InstrumentedClient decorates IAPIClient with OpenTelemetry span per call, latency histogram and error counter.
+// APIClient adds child span per attempt when ctx of the request carries the span.
+type InstrumentedClient struct {
+	next     contextClient
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	endpoint func(path string) string
+}
+
+func NewInstrumentedClient(next IAPIClient, configs ...func(*InstrumentationConfig)) (*InstrumentedClient, error) {
+	cfg := &InstrumentationConfig{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		endpoint:       EndpointTemplate,
+	}
+
+	for _, conf := range configs {
+		conf(cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		"fireblocks.client.request.duration",
+		metric.WithDescription("Duration of Fireblocks API calls including retries"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create duration histogram")
+	}
+
+	counter, err := meter.Int64Counter(
+		"fireblocks.client.request.errors",
+		metric.WithDescription("Number of failed Fireblocks API calls"),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create error counter")
+	}
+
+	return &InstrumentedClient{
+		next:     newContextClient(next),
+		tracer:   cfg.tracerProvider.Tracer(instrumentationName),
+		duration: duration,
+		errors:   counter,
+		endpoint: cfg.endpoint,
+	}, nil
+}
+
+type apiCall func(ctx context.Context) ([]byte, int, error)
+
+func (c *InstrumentedClient) observe(ctx context.Context, method, path string, call apiCall) ([]byte, int, error) {
+	endpoint := c.endpoint(path)
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("fireblocks.endpoint", endpoint),
+	}
+
+	ctx, span := c.tracer.Start(ctx, method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	started := time.Now()
+	body, status, err := call(ctx)
+
+	attrs = append(attrs, attribute.Int("http.response.status_code", status))
+	span.SetAttributes(attrs[len(attrs)-1])
+	c.duration.Record(ctx, time.Since(started).Seconds(), metric.WithAttributes(attrs...))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, attribute.String("error.type", errorType(err)))...))
+	}
+
+	return body, status, err
+}
+
+// errorType is the low cardinality kind of the error, HTTP status for APIError
+func errorType(err error) string {
+	var apiErr *APIError
+
+	switch {
+	case errors.As(err, &apiErr):
+		return strconv.Itoa(apiErr.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "transport"
+	}
+}
+
+func (c *InstrumentedClient) DoPostRequest(path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	return c.DoPostRequestWithContext(context.Background(), path, body, opts...)
+}
+
+func (c *InstrumentedClient) DoGetRequest(path string, q url.Values) ([]byte, int, error) {
+	return c.DoGetRequestWithContext(context.Background(), path, q)
+}
+
+func (c *InstrumentedClient) DoPutRequest(path string, body interface{}) ([]byte, int, error) {
+	return c.DoPutRequestWithContext(context.Background(), path, body)
+}
+
+func (c *InstrumentedClient) DoPatchRequest(path string, body interface{}) ([]byte, int, error) {
+	return c.DoPatchRequestWithContext(context.Background(), path, body)
+}
+
+func (c *InstrumentedClient) DoDeleteRequest(path string) ([]byte, int, error) {
+	return c.DoDeleteRequestWithContext(context.Background(), path)
+}
+
+func (c *InstrumentedClient) DoPostRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	return c.observe(ctx, http.MethodPost, path, func(ctx context.Context) ([]byte, int, error) {
+		return c.next.DoPostRequestWithContext(ctx, path, body, opts...)
+	})
+}
+
+func (c *InstrumentedClient) DoGetRequestWithContext(ctx context.Context, path string, q url.Values) ([]byte, int, error) {
+	return c.observe(ctx, http.MethodGet, path, func(ctx context.Context) ([]byte, int, error) {
+		return c.next.DoGetRequestWithContext(ctx, path, q)
+	})
+}
+
+func (c *InstrumentedClient) DoPutRequestWithContext(ctx context.Context, path string, body interface{}, opts ...func(*PostRequestOption)) ([]byte, int, error) {
+	return c.observe(ctx, http.MethodPut, path, func(ctx context.Context) ([]byte, int, error) {
+		return c.next.DoPutRequestWithContext(ctx, path, body, opts...)
+	})
+}
+
+func (c *InstrumentedClient) DoPatchRequestWithContext(ctx context.Context, path string, body interface{}) ([]byte, int, error) {
+	return c.observe(ctx, http.MethodPatch, path, func(ctx context.Context) ([]byte, int, error) {
+		return c.next.DoPatchRequestWithContext(ctx, path, body)
+	})
+}
+
+func (c *InstrumentedClient) DoDeleteRequestWithContext(ctx context.Context, path string) ([]byte, int, error) {
+	return c.observe(ctx, http.MethodDelete, path, func(ctx context.Context) ([]byte, int, error) {
+		return c.next.DoDeleteRequestWithContext(ctx, path)
+	})
+}
+
+// attemptTransport numbers the attempts of the request and traces each of them as the child
+// of the span found in ctx, requests without the span are not traced
+type attemptTransport struct {
+	next http.RoundTripper
+}
+
+func (t *attemptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, attempt := nextAttempt(req.Context())
+
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return t.next.RoundTrip(req.WithContext(ctx))
+	}
+
+	ctx, span := parent.TracerProvider().Tracer(instrumentationName).Start(ctx, "attempt "+strconv.Itoa(attempt),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.Int("http.request.resend_count", attempt-1),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
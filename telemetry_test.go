@@ -0,0 +1,163 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	sdk "fireblocksdk"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetrySuite(t *testing.T) {
+	suite.Run(t, new(TelemetrySuite))
+}
+
+type TelemetrySuite struct {
+	suite.Suite
+	spans  *tracetest.InMemoryExporter
+	reader *sdkmetric.ManualReader
+	opts   []func(o *sdk.SDKOptions)
+}
+
+func (suite *TelemetrySuite) SetupTest() {
+	suite.spans = tracetest.NewInMemoryExporter()
+	suite.reader = sdkmetric.NewManualReader()
+
+	suite.opts = []func(o *sdk.SDKOptions){
+		sdk.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(suite.spans))),
+		sdk.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(suite.reader))),
+		sdk.WithRetryWait(time.Millisecond, time.Millisecond),
+	}
+}
+
+func (suite *TelemetrySuite) metric(name string) metricdata.Aggregation {
+	data := metricdata.ResourceMetrics{}
+	require.NoError(suite.T(), suite.reader.Collect(context.Background(), &data))
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+
+	return nil
+}
+
+func (suite *TelemetrySuite) TestSpanPerCallWithAttemptChildren() {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":"12"}`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, suite.opts...)
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetVaultAccountsByID("12")
+	require.NoError(suite.T(), err)
+
+	spans := suite.spans.GetSpans()
+	require.Len(suite.T(), spans, 3)
+
+	// children end before the parent
+	first, second, parent := spans[0], spans[1], spans[2]
+	require.Equal(suite.T(), "GET /vault/accounts/{id}", parent.Name)
+	require.Contains(suite.T(), parent.Attributes, attribute.Int("http.response.status_code", http.StatusOK))
+
+	require.Equal(suite.T(), "attempt 1", first.Name)
+	require.Equal(suite.T(), codes.Error, first.Status.Code)
+	require.Equal(suite.T(), "attempt 2", second.Name)
+
+	for _, child := range []tracetest.SpanStub{first, second} {
+		require.Equal(suite.T(), parent.SpanContext.SpanID(), child.Parent.SpanID())
+		require.Equal(suite.T(), parent.SpanContext.TraceID(), child.SpanContext.TraceID())
+	}
+
+	histogram, ok := suite.metric("fireblocks.client.request.duration").(metricdata.Histogram[float64])
+	require.True(suite.T(), ok)
+	require.Len(suite.T(), histogram.DataPoints, 1)
+
+	point := histogram.DataPoints[0]
+	require.Equal(suite.T(), uint64(1), point.Count)
+
+	endpoint, _ := point.Attributes.Value("fireblocks.endpoint")
+	require.Equal(suite.T(), "/vault/accounts/{id}", endpoint.AsString())
+
+	status, _ := point.Attributes.Value("http.response.status_code")
+	require.Equal(suite.T(), int64(http.StatusOK), status.AsInt64())
+
+	require.Nil(suite.T(), suite.metric("fireblocks.client.request.errors"))
+}
+
+func (suite *TelemetrySuite) TestErrorsAreCounted() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":1004,"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), server.URL, suite.opts...)
+	require.NoError(suite.T(), err)
+
+	for i := 0; i < 2; i++ {
+		_, err = fb.GetVaultAccountsByID("404")
+		require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+	}
+
+	counter, ok := suite.metric("fireblocks.client.request.errors").(metricdata.Sum[int64])
+	require.True(suite.T(), ok)
+	require.Len(suite.T(), counter.DataPoints, 1)
+	require.Equal(suite.T(), int64(2), counter.DataPoints[0].Value)
+
+	errorType, _ := counter.DataPoints[0].Attributes.Value("error.type")
+	require.Equal(suite.T(), "404", errorType.AsString())
+
+	spans := suite.spans.GetSpans()
+	require.Equal(suite.T(), codes.Error, spans[len(spans)-1].Status.Code)
+}
+
+func (suite *TelemetrySuite) TestDecoratesCustomClient() {
+	client := &statusClient{status: http.StatusOK, body: []byte(`[]`)}
+
+	// options of the API client are rejected together with WithAPIClient, only instrumentation is kept
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), "", append(suite.opts[:2:2], sdk.WithAPIClient(client))...)
+	require.NoError(suite.T(), err)
+
+	_, err = fb.GetSupportedAssets()
+	require.NoError(suite.T(), err)
+
+	spans := suite.spans.GetSpans()
+	require.Len(suite.T(), spans, 1)
+	require.Equal(suite.T(), "GET /supported_assets", spans[0].Name)
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/supported_assets":                                         "/supported_assets",
+		"/vault/accounts/12/BTC_TEST/addresses":                     "/vault/accounts/{id}/{assetId}/addresses",
+		"/vault/accounts_paged?limit=2":                             "/vault/accounts_paged",
+		"/transactions/external_tx_id/order-42":                     "/transactions/external_tx_id/{externalTxId}",
+		"/transactions/4a8e5d33-5e3c-4d4a-9f3e-0a1b2c3d4e5f/cancel": "/transactions/{id}/cancel",
+	} {
+		require.Equal(t, expected, sdk.EndpointTemplate(path), path)
+	}
+}
@@ -0,0 +1,355 @@
+package fireblocksdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Transactions endpoint
+
+type TransactionStatus string
+
+const (
+	TransactionStatusSubmitted                TransactionStatus = "SUBMITTED"
+	TransactionStatusQueued                   TransactionStatus = "QUEUED"
+	TransactionStatusPendingAuthorization     TransactionStatus = "PENDING_AUTHORIZATION"
+	TransactionStatusPendingSignature         TransactionStatus = "PENDING_SIGNATURE"
+	TransactionStatusBroadcasting             TransactionStatus = "BROADCASTING"
+	TransactionStatusPending3rdPartyManual    TransactionStatus = "PENDING_3RD_PARTY_MANUAL_APPROVAL"
+	TransactionStatusPending3rdParty          TransactionStatus = "PENDING_3RD_PARTY"
+	TransactionStatusPending                  TransactionStatus = "PENDING" // Deprecated
+	TransactionStatusConfirming               TransactionStatus = "CONFIRMING"
+	TransactionStatusConfirmed                TransactionStatus = "CONFIRMED" // Deprecated
+	TransactionStatusCompleted                TransactionStatus = "COMPLETED"
+	TransactionStatusPendingAmlScreening      TransactionStatus = "PENDING_AML_SCREENING"
+	TransactionStatusPartiallyCompleted       TransactionStatus = "PARTIALLY_COMPLETED"
+	TransactionStatusCancelling               TransactionStatus = "CANCELLING"
+	TransactionStatusCancelled                TransactionStatus = "CANCELLED"
+	TransactionStatusRejected                 TransactionStatus = "REJECTED"
+	TransactionStatusFailed                   TransactionStatus = "FAILED"
+	TransactionStatusTimeout                  TransactionStatus = "TIMEOUT"
+	TransactionStatusBlocked                  TransactionStatus = "BLOCKED"
+	TransactionStatusPendingEnrichment        TransactionStatus = "PENDING_ENRICHMENT"
+	TransactionStatusPendingConsoleApproval   TransactionStatus = "PENDING_CONSOLE_APPROVAL"
+	TransactionStatusPendingAuthorizationSign TransactionStatus = "PENDING_AUTHORIZATION_SIGNATURE"
+)
+
+type PeerType string
+
+const (
+	PeerTypeVaultAccount      PeerType = "VAULT_ACCOUNT"
+	PeerTypeExchangeAccount   PeerType = "EXCHANGE_ACCOUNT"
+	PeerTypeInternalWallet    PeerType = "INTERNAL_WALLET"
+	PeerTypeExternalWallet    PeerType = "EXTERNAL_WALLET"
+	PeerTypeContract          PeerType = "CONTRACT"
+	PeerTypeNetworkConnection PeerType = "NETWORK_CONNECTION"
+	PeerTypeFiatAccount       PeerType = "FIAT_ACCOUNT"
+	PeerTypeCompound          PeerType = "COMPOUND"
+	PeerTypeGasStation        PeerType = "GAS_STATION"
+	PeerTypeOneTimeAddress    PeerType = "ONE_TIME_ADDRESS"
+	PeerTypeUnknown           PeerType = "UNKNOWN"
+)
+
+type TransactionOperation string
+
+const (
+	TransactionOperationTransfer         TransactionOperation = "TRANSFER"
+	TransactionOperationMint             TransactionOperation = "MINT"
+	TransactionOperationBurn             TransactionOperation = "BURN"
+	TransactionOperationContractCall     TransactionOperation = "CONTRACT_CALL"
+	TransactionOperationTypedMessage     TransactionOperation = "TYPED_MESSAGE"
+	TransactionOperationRaw              TransactionOperation = "RAW"
+	TransactionOperationSupplyToCompound TransactionOperation = "SUPPLY_TO_COMPOUND"
+	TransactionOperationRedeemCompound   TransactionOperation = "REDEEM_FROM_COMPOUND"
+)
+
+type FeeLevel string
+
+const (
+	FeeLevelLow    FeeLevel = "LOW"
+	FeeLevelMedium FeeLevel = "MEDIUM"
+	FeeLevelHigh   FeeLevel = "HIGH"
+)
+
+// Requests
+
+/*
+export interface TransferPeerPath {
+    type?: PeerType;
+    id?: string;
+    walletId?: string;
+    virtualId?: string;
+    virtualType?: VirtualType;
+    address?: string;
+}
+*/
+
+// OneTimeAddress defines destination which is not whitelisted.
+type OneTimeAddress struct {
+	Address string `json:"address"`
+	Tag     string `json:"tag,omitempty"`
+}
+
+// TransferPeerPath defines source or destination of a transaction.
+type TransferPeerPath struct {
+	Type           PeerType        `json:"type,omitempty"`
+	ID             string          `json:"id,omitempty"`
+	WalletID       string          `json:"walletId,omitempty"`
+	OneTimeAddress *OneTimeAddress `json:"oneTimeAddress,omitempty"`
+}
+
+// TransactionDestination defines one of the destinations of a multi-destination transaction.
+type TransactionDestination struct {
+	Amount      string           `json:"amount"`
+	Destination TransferPeerPath `json:"destination"`
+}
+
+// TransactionRequest defines model for POST /transactions.
+type TransactionRequest struct {
+	AssetID            string                   `json:"assetId,omitempty"`
+	Source             *TransferPeerPath        `json:"source,omitempty"`
+	Destination        *TransferPeerPath        `json:"destination,omitempty"`
+	Destinations       []TransactionDestination `json:"destinations,omitempty"`
+	Amount             string                   `json:"amount,omitempty"`
+	Operation          TransactionOperation     `json:"operation,omitempty"`
+	Fee                string                   `json:"fee,omitempty"`
+	FeeLevel           FeeLevel                 `json:"feeLevel,omitempty"`
+	FailOnLowFee       *bool                    `json:"failOnLowFee,omitempty"`
+	MaxFee             string                   `json:"maxFee,omitempty"`
+	PriorityFee        string                   `json:"priorityFee,omitempty"`
+	GasPrice           string                   `json:"gasPrice,omitempty"`
+	GasLimit           string                   `json:"gasLimit,omitempty"`
+	NetworkFee         string                   `json:"networkFee,omitempty"`
+	Note               string                   `json:"note,omitempty"`
+	CustomerRefID      string                   `json:"customerRefId,omitempty"`
+	ExternalTxID       string                   `json:"externalTxId,omitempty"`
+	TreatAsGrossAmount *bool                    `json:"treatAsGrossAmount,omitempty"`
+	ForceSweep         *bool                    `json:"forceSweep,omitempty"`
+	ReplaceTxByHash    string                   `json:"replaceTxByHash,omitempty"`
+	ExtraParameters    map[string]interface{}   `json:"extraParameters,omitempty"`
+}
+
+// TransactionsFilter defines parameters for GetTransactions.
+type TransactionsFilter struct {
+	Before         string `json:"before,omitempty"`  // Unix timestamp in milliseconds, returns only transactions that were created before it
+	After          string `json:"after,omitempty"`   // Unix timestamp in milliseconds, returns only transactions that were created after it
+	Status         string `json:"status,omitempty"`  // Comma separated list of statuses
+	OrderBy        string `json:"orderBy,omitempty"` // createdAt | lastUpdated
+	Sort           string `json:"sort,omitempty"`    // ASC | DESC
+	Limit          int64  `json:"limit,omitempty"`   // The default value is 200 and maximum value is 500.
+	SourceType     string `json:"sourceType,omitempty"`
+	SourceID       string `json:"sourceId,omitempty"`
+	DestType       string `json:"destType,omitempty"`
+	DestID         string `json:"destId,omitempty"`
+	Assets         string `json:"assets,omitempty"` // Comma separated list of asset IDs
+	TxHash         string `json:"txHash,omitempty"`
+	SourceWalletID string `json:"sourceWalletId,omitempty"`
+	DestWalletID   string `json:"destWalletId,omitempty"`
+}
+
+// DropTransactionRequest defines model for POST /transactions/{id}/drop.
+type DropTransactionRequest struct {
+	FeeLevel     FeeLevel `json:"feeLevel,omitempty"`
+	RequestedFee string   `json:"requestedFee,omitempty"`
+}
+
+// Responses
+
+type CreateTransactionResponse struct {
+	ID     string            `json:"id"`
+	Status TransactionStatus `json:"status"`
+}
+
+// TransferPeerPathResponse defines source or destination as returned by Fireblocks.
+type TransferPeerPathResponse struct {
+	Type    PeerType `json:"type,omitempty"`
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	SubType string   `json:"subType,omitempty"`
+}
+
+type AmountInfo struct {
+	Amount          string `json:"amount,omitempty"`
+	RequestedAmount string `json:"requestedAmount,omitempty"`
+	NetAmount       string `json:"netAmount,omitempty"`
+	AmountUSD       string `json:"amountUSD,omitempty"`
+}
+
+type FeeInfo struct {
+	NetworkFee string `json:"networkFee,omitempty"`
+	ServiceFee string `json:"serviceFee,omitempty"`
+	GasPrice   string `json:"gasPrice,omitempty"`
+}
+
+type BlockInfo struct {
+	BlockHeight string `json:"blockHeight,omitempty"`
+	BlockHash   string `json:"blockHash,omitempty"`
+}
+
+type TransactionDestinationResponse struct {
+	Amount                        string                   `json:"amount,omitempty"`
+	AmountUSD                     string                   `json:"amountUSD,omitempty"`
+	AmlScreeningResult            map[string]interface{}   `json:"amlScreeningResult,omitempty"`
+	Destination                   TransferPeerPathResponse `json:"destination"`
+	AuthorizationInfo             map[string]interface{}   `json:"authorizationInfo,omitempty"`
+	DestinationAddress            string                   `json:"destinationAddress,omitempty"`
+	DestinationAddressDescription string                   `json:"destinationAddressDescription,omitempty"`
+	CustomerRefID                 string                   `json:"customerRefId,omitempty"`
+}
+
+// TransactionResponse defines model for GET /transactions/{id}.
+type TransactionResponse struct {
+	ID                            string                           `json:"id"`
+	AssetID                       string                           `json:"assetId,omitempty"`
+	Source                        TransferPeerPathResponse         `json:"source"`
+	Destination                   TransferPeerPathResponse         `json:"destination"`
+	Destinations                  []TransactionDestinationResponse `json:"destinations,omitempty"`
+	RequestedAmount               float64                          `json:"requestedAmount,omitempty"` // Deprecated - replaced by "amountInfo"
+	Amount                        float64                          `json:"amount,omitempty"`          // Deprecated - replaced by "amountInfo"
+	NetAmount                     float64                          `json:"netAmount,omitempty"`       // Deprecated - replaced by "amountInfo"
+	AmountUSD                     float64                          `json:"amountUSD,omitempty"`       // Deprecated - replaced by "amountInfo"
+	ServiceFee                    float64                          `json:"serviceFee,omitempty"`      // Deprecated - replaced by "feeInfo"
+	NetworkFee                    float64                          `json:"networkFee,omitempty"`      // Deprecated - replaced by "feeInfo"
+	AmountInfo                    *AmountInfo                      `json:"amountInfo,omitempty"`
+	FeeInfo                       *FeeInfo                         `json:"feeInfo,omitempty"`
+	CreatedAt                     int64                            `json:"createdAt,omitempty"`   // Unix timestamp in milliseconds
+	LastUpdated                   int64                            `json:"lastUpdated,omitempty"` // Unix timestamp in milliseconds
+	Status                        TransactionStatus                `json:"status"`
+	SubStatus                     string                           `json:"subStatus,omitempty"`
+	TxHash                        string                           `json:"txHash,omitempty"`
+	Tag                           string                           `json:"tag,omitempty"`
+	SourceAddress                 string                           `json:"sourceAddress,omitempty"`
+	DestinationAddress            string                           `json:"destinationAddress,omitempty"`
+	DestinationAddressDescription string                           `json:"destinationAddressDescription,omitempty"`
+	DestinationTag                string                           `json:"destinationTag,omitempty"`
+	SignedBy                      []string                         `json:"signedBy,omitempty"`
+	CreatedBy                     string                           `json:"createdBy,omitempty"`
+	RejectedBy                    string                           `json:"rejectedBy,omitempty"`
+	AddressType                   string                           `json:"addressType,omitempty"`
+	Note                          string                           `json:"note,omitempty"`
+	ExchangeTxID                  string                           `json:"exchangeTxId,omitempty"`
+	FeeCurrency                   string                           `json:"feeCurrency,omitempty"`
+	Operation                     TransactionOperation             `json:"operation,omitempty"`
+	NumOfConfirmations            int64                            `json:"numOfConfirmations,omitempty"`
+	ExternalTxID                  string                           `json:"externalTxId,omitempty"`
+	CustomerRefID                 string                           `json:"customerRefId,omitempty"`
+	BlockInfo                     *BlockInfo                       `json:"blockInfo,omitempty"`
+	ReplacedTxHash                string                           `json:"replacedTxHash,omitempty"`
+	ExtraParameters               map[string]interface{}           `json:"extraParameters,omitempty"`
+}
+
+type OperationSuccessResponse struct {
+	Success bool `json:"success"`
+}
+
+type DropTransactionResponse struct {
+	Success      bool     `json:"success"`
+	Transactions []string `json:"transactions,omitempty"`
+}
+
+// CreateTransaction Submits a new transaction
+func (sdk *FireblocksSDK) CreateTransaction(tx *TransactionRequest, opts ...func(*PostRequestOption)) (resp *CreateTransactionResponse, err error) {
+	return sdk.CreateTransactionWithContext(context.Background(), tx, opts...)
+}
+
+// CreateTransactionWithContext is CreateTransaction with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateTransactionWithContext(ctx context.Context, tx *TransactionRequest, opts ...func(*PostRequestOption)) (resp *CreateTransactionResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, "/transactions", tx, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// GetTransactionByID Retrieves a transaction by Fireblocks transaction ID
+func (sdk *FireblocksSDK) GetTransactionByID(txID string) (resp *TransactionResponse, err error) {
+	return sdk.GetTransactionByIDWithContext(context.Background(), txID)
+}
+
+// GetTransactionByIDWithContext is GetTransactionByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetTransactionByIDWithContext(ctx context.Context, txID string) (resp *TransactionResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/transactions/%s", url.PathEscape(txID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetTransactionByExternalTxID Retrieves a transaction by the external ID given on creation
+func (sdk *FireblocksSDK) GetTransactionByExternalTxID(externalTxID string) (resp *TransactionResponse, err error) {
+	return sdk.GetTransactionByExternalTxIDWithContext(context.Background(), externalTxID)
+}
+
+// GetTransactionByExternalTxIDWithContext is GetTransactionByExternalTxID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetTransactionByExternalTxIDWithContext(ctx context.Context, externalTxID string) (resp *TransactionResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/transactions/external_tx_id/%s", url.PathEscape(externalTxID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// GetTransactions Lists the transaction history, filtered by q
+func (sdk *FireblocksSDK) GetTransactions(q *TransactionsFilter) (resp []*TransactionResponse, err error) {
+	return sdk.GetTransactionsWithContext(context.Background(), q)
+}
+
+// GetTransactionsWithContext is GetTransactions with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetTransactionsWithContext(ctx context.Context, q *TransactionsFilter) (resp []*TransactionResponse, err error) {
+	query := BuildQuery(q).URLValues()
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/transactions", query)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// CancelTransactionByID Cancels a transaction which is not yet signed
+func (sdk *FireblocksSDK) CancelTransactionByID(txID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.CancelTransactionByIDWithContext(context.Background(), txID, opts...)
+}
+
+// CancelTransactionByIDWithContext is CancelTransactionByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CancelTransactionByIDWithContext(ctx context.Context, txID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/transactions/%s/cancel", url.PathEscape(txID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// DropTransaction Drops a stuck ETH transaction and creates a replacement transaction
+func (sdk *FireblocksSDK) DropTransaction(txID string, drop *DropTransactionRequest, opts ...func(*PostRequestOption)) (resp *DropTransactionResponse, err error) {
+	return sdk.DropTransactionWithContext(context.Background(), txID, drop, opts...)
+}
+
+// DropTransactionWithContext is DropTransaction with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DropTransactionWithContext(ctx context.Context, txID string, drop *DropTransactionRequest, opts ...func(*PostRequestOption)) (resp *DropTransactionResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/transactions/%s/drop", url.PathEscape(txID)), drop, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// FreezeTransactionByID Freezes the funds of an incoming transaction
+func (sdk *FireblocksSDK) FreezeTransactionByID(txID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.FreezeTransactionByIDWithContext(context.Background(), txID, opts...)
+}
+
+// FreezeTransactionByIDWithContext is FreezeTransactionByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) FreezeTransactionByIDWithContext(ctx context.Context, txID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/transactions/%s/freeze", url.PathEscape(txID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// UnfreezeTransactionByID Unfreezes the funds of an incoming transaction
+func (sdk *FireblocksSDK) UnfreezeTransactionByID(txID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.UnfreezeTransactionByIDWithContext(context.Background(), txID, opts...)
+}
+
+// UnfreezeTransactionByIDWithContext is UnfreezeTransactionByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) UnfreezeTransactionByIDWithContext(ctx context.Context, txID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/transactions/%s/unfreeze", url.PathEscape(txID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
@@ -0,0 +1,147 @@
+package fireblocksdk_test
+
+import (
+	"encoding/json"
+	sdk "fireblocksdk"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTransactionsSuite(t *testing.T) {
+	suite.Run(t, new(TransactionsSuite))
+}
+
+type TransactionsSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	sdk      *sdk.FireblocksSDK
+	method   string
+	uri      string
+	body     []byte
+	response string
+}
+
+func (suite *TransactionsSuite) SetupTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.method = r.Method
+		suite.uri = r.URL.RequestURI()
+		suite.body, _ = ioutil.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(suite.response))
+	}))
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), suite.server.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+}
+
+func (suite *TransactionsSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *TransactionsSuite) TestCreateTransaction() {
+	suite.response = `{"id":"tx-1","status":"SUBMITTED"}`
+
+	resp, err := suite.sdk.CreateTransaction(&sdk.TransactionRequest{
+		AssetID:     "BTC_TEST",
+		Source:      &sdk.TransferPeerPath{Type: sdk.PeerTypeVaultAccount, ID: "0"},
+		Destination: &sdk.TransferPeerPath{Type: sdk.PeerTypeOneTimeAddress, OneTimeAddress: &sdk.OneTimeAddress{Address: "tb1q"}},
+		Amount:      "0.001",
+		FeeLevel:    sdk.FeeLevelMedium,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "tx-1", resp.ID)
+	require.Equal(suite.T(), sdk.TransactionStatusSubmitted, resp.Status)
+
+	require.Equal(suite.T(), http.MethodPost, suite.method)
+	require.Equal(suite.T(), "/v1/transactions", suite.uri)
+
+	sent := map[string]interface{}{}
+	require.NoError(suite.T(), json.Unmarshal(suite.body, &sent))
+	require.Equal(suite.T(), "BTC_TEST", sent["assetId"])
+	require.Equal(suite.T(), "MEDIUM", sent["feeLevel"])
+	require.Equal(suite.T(), map[string]interface{}{"type": "VAULT_ACCOUNT", "id": "0"}, sent["source"])
+	require.Equal(suite.T(), map[string]interface{}{"type": "ONE_TIME_ADDRESS", "oneTimeAddress": map[string]interface{}{"address": "tb1q"}}, sent["destination"])
+}
+
+func (suite *TransactionsSuite) TestGetTransactionByID() {
+	suite.response = `{"id":"tx-1","status":"COMPLETED","subStatus":"CONFIRMED","source":{"type":"VAULT_ACCOUNT","id":"0"},"amountInfo":{"amount":"1"}}`
+
+	resp, err := suite.sdk.GetTransactionByID("tx-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), http.MethodGet, suite.method)
+	require.Equal(suite.T(), "/v1/transactions/tx-1", suite.uri)
+	require.Equal(suite.T(), sdk.TransactionStatusCompleted, resp.Status)
+	require.Equal(suite.T(), sdk.PeerTypeVaultAccount, resp.Source.Type)
+	require.Equal(suite.T(), "1", resp.AmountInfo.Amount)
+}
+
+func (suite *TransactionsSuite) TestGetTransactionByExternalTxID() {
+	suite.response = `{"id":"tx-1","status":"SUBMITTED","externalTxId":"ext-1"}`
+
+	resp, err := suite.sdk.GetTransactionByExternalTxID("ext-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "/v1/transactions/external_tx_id/ext-1", suite.uri)
+	require.Equal(suite.T(), "ext-1", resp.ExternalTxID)
+}
+
+func (suite *TransactionsSuite) TestPathIDsAreEscaped() {
+	suite.response = `{"id":"tx-1","status":"SUBMITTED","externalTxId":"order/1 #2"}`
+
+	resp, err := suite.sdk.GetTransactionByExternalTxID("order/1 #2")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "/v1/transactions/external_tx_id/order%2F1%20%232", suite.uri)
+	require.Equal(suite.T(), "order/1 #2", resp.ExternalTxID)
+
+	suite.response = `{"success":true}`
+
+	_, err = suite.sdk.CancelTransactionByID("../1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "/v1/transactions/..%2F1/cancel", suite.uri)
+}
+
+func (suite *TransactionsSuite) TestGetTransactionsWithFilters() {
+	suite.response = `[{"id":"tx-1","status":"COMPLETED"},{"id":"tx-2","status":"FAILED"}]`
+
+	resp, err := suite.sdk.GetTransactions(&sdk.TransactionsFilter{
+		Status: "COMPLETED,FAILED",
+		Limit:  2,
+		Assets: "BTC_TEST",
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), resp, 2)
+	require.Equal(suite.T(), "/v1/transactions?assets=BTC_TEST&limit=2&status=COMPLETED%2CFAILED", suite.uri)
+}
+
+func (suite *TransactionsSuite) TestTransactionActions() {
+	suite.response = `{"success":true}`
+
+	resp, err := suite.sdk.CancelTransactionByID("tx-1")
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+	require.Equal(suite.T(), http.MethodPost, suite.method)
+	require.Equal(suite.T(), "/v1/transactions/tx-1/cancel", suite.uri)
+
+	_, err = suite.sdk.FreezeTransactionByID("tx-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "/v1/transactions/tx-1/freeze", suite.uri)
+
+	_, err = suite.sdk.UnfreezeTransactionByID("tx-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "/v1/transactions/tx-1/unfreeze", suite.uri)
+
+	suite.response = `{"success":true,"transactions":["tx-2"]}`
+
+	drop, err := suite.sdk.DropTransaction("tx-1", &sdk.DropTransactionRequest{FeeLevel: sdk.FeeLevelHigh})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "/v1/transactions/tx-1/drop", suite.uri)
+	require.Equal(suite.T(), []string{"tx-2"}, drop.Transactions)
+	require.JSONEq(suite.T(), `{"feeLevel":"HIGH"}`, string(suite.body))
+}
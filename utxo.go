@@ -0,0 +1,310 @@
+package fireblocksdk
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInsufficientFunds is returned when the unspent inputs do not cover the amount
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+const (
+	// maxBranchAndBoundTries limits the search, the same limit as Bitcoin Core uses
+	maxBranchAndBoundTries = 100000
+	// maxDecimalDigits bounds the precision of amounts which have no finite decimal form, e.g. "1/3"
+	maxDecimalDigits = 64
+
+	// UTXOStatusFrozen is the status of the input which can't be spent
+	UTXOStatusFrozen = "FROZEN"
+)
+
+/*
+{
+    "input": {
+        "txHash": "string",
+        "index": "number"
+    },
+    "address": "string",
+    "amount": "string",
+    "confirmations": "number",
+    "status": "string"
+}
+*/
+
+// UnspentInput identifies the output of the previous transaction
+type UnspentInput struct {
+	TxHash string `json:"txHash"`
+	Index  int    `json:"index"`
+}
+
+// UnspentInputsResponse defines model for UnspentInputsData.
+type UnspentInputsResponse struct {
+	Input         UnspentInput `json:"input"`
+	Address       string       `json:"address,omitempty"`
+	Amount        string       `json:"amount,omitempty"`
+	Confirmations int64        `json:"confirmations,omitempty"`
+	Status        string       `json:"status,omitempty"`
+}
+
+// InputsSelection is extraParameters.inputsSelection of the transaction
+type InputsSelection struct {
+	InputsToSpend   []UnspentInput `json:"inputsToSpend,omitempty"`
+	InputsToExclude []UnspentInput `json:"inputsToExclude,omitempty"`
+}
+
+// SetInputsSelection makes the transaction spend exactly the selected inputs
+func (tx *TransactionRequest) SetInputsSelection(selection *InputsSelection) {
+	if tx.ExtraParameters == nil {
+		tx.ExtraParameters = map[string]interface{}{}
+	}
+
+	tx.ExtraParameters["inputsSelection"] = selection
+}
+
+// UTXOSelection is the result of the input selection, the fee is not modelled,
+// so amount to select should already include the expected fee
+type UTXOSelection struct {
+	Inputs []*UnspentInputsResponse
+	Total  string // Sum of the selected inputs
+	Change string // Total minus the selected amount
+}
+
+// InputsSelection returns the selection in the form accepted by TransactionRequest.SetInputsSelection
+func (s *UTXOSelection) InputsSelection() *InputsSelection {
+	selection := &InputsSelection{}
+	for _, utxo := range s.Inputs {
+		selection.InputsToSpend = append(selection.InputsToSpend, utxo.Input)
+	}
+
+	return selection
+}
+
+type UTXOSelectionOptions struct {
+	minConfirmations int64
+	filter           func(*UnspentInputsResponse) bool
+}
+
+// DefaultUTXOSelectionOptions selects only inputs with at least one confirmation which are not frozen
+func DefaultUTXOSelectionOptions() *UTXOSelectionOptions {
+	return &UTXOSelectionOptions{minConfirmations: 1}
+}
+
+// WithMinConfirmations skips inputs with less confirmations, 0 allows unconfirmed inputs
+func WithMinConfirmations(confirmations int64) func(*UTXOSelectionOptions) {
+	return func(o *UTXOSelectionOptions) {
+		o.minConfirmations = confirmations
+	}
+}
+
+// WithInputFilter skips inputs for which filter returns false, frozen inputs are skipped regardless of it
+func WithInputFilter(filter func(*UnspentInputsResponse) bool) func(*UTXOSelectionOptions) {
+	return func(o *UTXOSelectionOptions) {
+		o.filter = filter
+	}
+}
+
+func (o *UTXOSelectionOptions) spendable(utxo *UnspentInputsResponse) bool {
+	if utxo.Status == UTXOStatusFrozen || utxo.Confirmations < o.minConfirmations {
+		return false
+	}
+
+	return o.filter == nil || o.filter(utxo)
+}
+
+func newUTXOSelectionOptions(opts []func(*UTXOSelectionOptions)) *UTXOSelectionOptions {
+	o := DefaultUTXOSelectionOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+type weightedInput struct {
+	utxo   *UnspentInputsResponse
+	amount *big.Rat
+}
+
+// sortedInputs parses amounts of the spendable inputs and orders them from the largest one
+func sortedInputs(utxos []*UnspentInputsResponse, o *UTXOSelectionOptions) ([]weightedInput, error) {
+	inputs := make([]weightedInput, 0, len(utxos))
+
+	for _, utxo := range utxos {
+		if !o.spendable(utxo) {
+			continue
+		}
+
+		amount, ok := new(big.Rat).SetString(utxo.Amount)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q of input %s:%d", utxo.Amount, utxo.Input.TxHash, utxo.Input.Index)
+		}
+
+		inputs = append(inputs, weightedInput{utxo, amount})
+	}
+
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return inputs[i].amount.Cmp(inputs[j].amount) > 0
+	})
+
+	return inputs, nil
+}
+
+func parseAmount(amount string) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	return value, nil
+}
+
+func newUTXOSelection(selected []weightedInput, target *big.Rat, digits int) *UTXOSelection {
+	total := new(big.Rat)
+	selection := &UTXOSelection{}
+
+	for _, input := range selected {
+		total.Add(total, input.amount)
+		selection.Inputs = append(selection.Inputs, input.utxo)
+	}
+
+	selection.Total = total.FloatString(digits)
+	selection.Change = new(big.Rat).Sub(total, target).FloatString(digits)
+
+	return selection
+}
+
+// scale returns the number of digits after the decimal point needed to show every amount exactly,
+// trailing zeros and exponents like "1e-8" don't matter as the precision comes from the reduced denominator
+func scale(target *big.Rat, inputs []weightedInput) int {
+	digits := decimalDigits(target)
+	for _, input := range inputs {
+		if d := decimalDigits(input.amount); d > digits {
+			digits = d
+		}
+	}
+
+	return digits
+}
+
+// decimalDigits returns the smallest n for which value*10^n is an integer
+func decimalDigits(value *big.Rat) int {
+	var (
+		ten   = big.NewInt(10)
+		power = big.NewInt(1)
+		rem   = new(big.Int)
+	)
+
+	for digits := 0; digits < maxDecimalDigits; digits++ {
+		if rem.Mod(power, value.Denom()).Sign() == 0 {
+			return digits
+		}
+
+		power.Mul(power, ten)
+	}
+
+	return maxDecimalDigits
+}
+
+// SelectInputsLargestFirst takes the largest spendable inputs until their sum covers amount
+func SelectInputsLargestFirst(utxos []*UnspentInputsResponse, amount string, opts ...func(*UTXOSelectionOptions)) (*UTXOSelection, error) {
+	target, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := sortedInputs(utxos, newUTXOSelectionOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Rat)
+	for i, input := range inputs {
+		total.Add(total, input.amount)
+		if total.Cmp(target) >= 0 {
+			return newUTXOSelection(inputs[:i+1], target, scale(target, inputs)), nil
+		}
+	}
+
+	return nil, errors.Wrapf(ErrInsufficientFunds, "spendable inputs sum to %s, %s is required", total.FloatString(scale(target, inputs)), amount)
+}
+
+// SelectInputsBranchAndBound searches for the spendable inputs which exceed amount by at most maxExcess,
+// so the transaction needs no change output, the set with the smallest excess wins.
+// When there is no such set it falls back to SelectInputsLargestFirst.
+func SelectInputsBranchAndBound(utxos []*UnspentInputsResponse, amount, maxExcess string, opts ...func(*UTXOSelectionOptions)) (*UTXOSelection, error) {
+	target, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	excess, ok := new(big.Rat).SetString(maxExcess)
+	if !ok || excess.Sign() < 0 {
+		return nil, fmt.Errorf("invalid max excess %q", maxExcess)
+	}
+
+	inputs, err := sortedInputs(utxos, newUTXOSelectionOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	// remaining[i] is the sum of inputs[i:], the branch is cut when it cannot reach the target
+	remaining := make([]*big.Rat, len(inputs)+1)
+	remaining[len(inputs)] = new(big.Rat)
+	for i := len(inputs) - 1; i >= 0; i-- {
+		remaining[i] = new(big.Rat).Add(remaining[i+1], inputs[i].amount)
+	}
+
+	upper := new(big.Rat).Add(target, excess)
+
+	var (
+		best      []weightedInput
+		bestTotal *big.Rat
+		tries     int
+		selected  []weightedInput
+	)
+
+	var search func(i int, total *big.Rat) bool
+	search = func(i int, total *big.Rat) bool {
+		tries++
+		if tries > maxBranchAndBoundTries {
+			return true
+		}
+
+		if total.Cmp(upper) > 0 {
+			return false
+		}
+
+		if total.Cmp(target) >= 0 {
+			if bestTotal == nil || total.Cmp(bestTotal) < 0 {
+				best = append([]weightedInput(nil), selected...)
+				bestTotal = total
+			}
+
+			// exact match cannot be improved
+			return total.Cmp(target) == 0
+		}
+
+		if i == len(inputs) || new(big.Rat).Add(total, remaining[i]).Cmp(target) < 0 {
+			return false
+		}
+
+		selected = append(selected, inputs[i])
+		if search(i+1, new(big.Rat).Add(total, inputs[i].amount)) {
+			return true
+		}
+		selected = selected[:len(selected)-1]
+
+		return search(i+1, total)
+	}
+
+	search(0, new(big.Rat))
+
+	if best == nil {
+		return SelectInputsLargestFirst(utxos, amount, opts...)
+	}
+
+	return newUTXOSelection(best, target, scale(target, inputs)), nil
+}
@@ -0,0 +1,155 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unspentInputs(amounts ...string) []*sdk.UnspentInputsResponse {
+	utxos := make([]*sdk.UnspentInputsResponse, 0, len(amounts))
+	for i, amount := range amounts {
+		utxos = append(utxos, &sdk.UnspentInputsResponse{
+			Input:         sdk.UnspentInput{TxHash: fmt.Sprintf("hash-%d", i), Index: i},
+			Amount:        amount,
+			Confirmations: 1,
+		})
+	}
+
+	return utxos
+}
+
+func selectedAmounts(selection *sdk.UTXOSelection) []string {
+	var amounts []string
+	for _, utxo := range selection.Inputs {
+		amounts = append(amounts, utxo.Amount)
+	}
+
+	return amounts
+}
+
+func TestSelectInputsLargestFirst(t *testing.T) {
+	selection, err := sdk.SelectInputsLargestFirst(unspentInputs("0.1", "0.5", "0.05", "0.2"), "0.6")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.5", "0.2"}, selectedAmounts(selection))
+	require.Equal(t, "0.70", selection.Total)
+	require.Equal(t, "0.10", selection.Change)
+
+	_, err = sdk.SelectInputsLargestFirst(unspentInputs("0.1", "0.5"), "1")
+	require.ErrorIs(t, err, sdk.ErrInsufficientFunds)
+
+	_, err = sdk.SelectInputsLargestFirst(unspentInputs("abc"), "1")
+	require.Error(t, err)
+
+	_, err = sdk.SelectInputsLargestFirst(unspentInputs("0.1"), "-1")
+	require.Error(t, err)
+}
+
+func TestSelectInputsBranchAndBound(t *testing.T) {
+	utxos := unspentInputs("0.1", "0.5", "0.05", "0.2")
+
+	selection, err := sdk.SelectInputsBranchAndBound(utxos, "0.3", "0.01")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.2", "0.1"}, selectedAmounts(selection))
+	require.Equal(t, "0.00", selection.Change)
+
+	selection, err = sdk.SelectInputsBranchAndBound(utxos, "0.34", "0.02")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.2", "0.1", "0.05"}, selectedAmounts(selection))
+	require.Equal(t, "0.01", selection.Change)
+
+	// no set within the excess, largest first is used
+	selection, err = sdk.SelectInputsBranchAndBound(utxos, "0.33", "0.001")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.5"}, selectedAmounts(selection))
+
+	_, err = sdk.SelectInputsBranchAndBound(utxos, "2", "0.1")
+	require.ErrorIs(t, err, sdk.ErrInsufficientFunds)
+}
+
+func TestSelectionPrecision(t *testing.T) {
+	selection, err := sdk.SelectInputsLargestFirst(unspentInputs("1e-8", "0.50000000"), "0.5")
+	require.NoError(t, err)
+	require.Equal(t, "0.50000000", selection.Total)
+	require.Equal(t, "0.00000000", selection.Change)
+
+	selection, err = sdk.SelectInputsLargestFirst(unspentInputs("0.5000", "1e-8"), "0.50000001")
+	require.NoError(t, err)
+	require.Equal(t, "0.50000001", selection.Total)
+
+	selection, err = sdk.SelectInputsLargestFirst(unspentInputs("1.10000000"), "1")
+	require.NoError(t, err)
+	require.Equal(t, "1.1", selection.Total)
+	require.Equal(t, "0.1", selection.Change)
+}
+
+func TestSelectionSkipsUnspendableInputs(t *testing.T) {
+	utxos := unspentInputs("0.5", "0.3", "0.2", "0.1")
+	utxos[0].Confirmations = 0
+	utxos[1].Status = sdk.UTXOStatusFrozen
+
+	selection, err := sdk.SelectInputsLargestFirst(utxos, "0.3")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.2", "0.1"}, selectedAmounts(selection))
+
+	selection, err = sdk.SelectInputsBranchAndBound(utxos, "0.5", "0")
+	require.ErrorIs(t, err, sdk.ErrInsufficientFunds)
+	require.Nil(t, selection)
+
+	selection, err = sdk.SelectInputsBranchAndBound(utxos, "0.5", "0", sdk.WithMinConfirmations(0))
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.5"}, selectedAmounts(selection))
+
+	selection, err = sdk.SelectInputsLargestFirst(utxos, "0.1", sdk.WithInputFilter(func(utxo *sdk.UnspentInputsResponse) bool {
+		return utxo.Input.TxHash != "hash-2"
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.1"}, selectedAmounts(selection))
+}
+
+func TestInputsSelectionFeedsTransaction(t *testing.T) {
+	srv := fireblockstest.NewServer()
+	defer srv.Close()
+
+	accountID := srv.AddVaultAccount("vault")
+	require.NoError(t, srv.SetAssetBalance(accountID, "BTC_TEST", "0.85"))
+
+	for _, utxo := range unspentInputs("0.1", "0.5", "0.05", "0.2") {
+		utxo.Confirmations = 6
+		require.NoError(t, srv.AddUnspentInput(accountID, "BTC_TEST", *utxo))
+	}
+
+	fb, err := sdk.CreateSDK(srv.APIKey, srv.PrivateKeyPEM(), srv.URL)
+	require.NoError(t, err)
+
+	utxos, err := fb.GetUnspentInputs(accountID, "BTC_TEST")
+	require.NoError(t, err)
+	require.Len(t, utxos, 4)
+	require.Equal(t, sdk.UnspentInput{TxHash: "hash-1", Index: 1}, utxos[1].Input)
+	require.Equal(t, int64(6), utxos[1].Confirmations)
+
+	selection, err := sdk.SelectInputsBranchAndBound(utxos, "0.3", "0")
+	require.NoError(t, err)
+
+	tx := &sdk.TransactionRequest{
+		AssetID: "BTC_TEST",
+		Source:  &sdk.TransferPeerPath{Type: sdk.PeerTypeVaultAccount, ID: accountID},
+		Amount:  "0.3",
+	}
+	tx.SetInputsSelection(selection.InputsSelection())
+
+	created, err := fb.CreateTransaction(tx)
+	require.NoError(t, err)
+
+	stored, ok := srv.Transaction(created.ID)
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{
+		"inputsToSpend": []interface{}{
+			map[string]interface{}{"txHash": "hash-3", "index": float64(3)},
+			map[string]interface{}{"txHash": "hash-0", "index": float64(0)},
+		},
+	}, stored.ExtraParameters["inputsSelection"])
+}
@@ -1,11 +1,11 @@
 package fireblocksdk
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
 )
 
 // VaultAccount endpoint
@@ -160,88 +160,109 @@ type PostOptions struct {
 
 // GetVaultAccounts Deprecated, Gets all assets that are currently supported by Fireblocks,
 func (sdk *FireblocksSDK) GetVaultAccounts(q *VaultAccountsFilter) (resp []*VaultAccountResponse, err error) {
+	return sdk.GetVaultAccountsWithContext(context.Background(), q)
+}
+
+// GetVaultAccountsWithContext is GetVaultAccounts with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetVaultAccountsWithContext(ctx context.Context, q *VaultAccountsFilter) (resp []*VaultAccountResponse, err error) {
 	query := BuildQuery(q).URLValues()
-	body, status, err := sdk.client.DoGetRequest("/vault/accounts", query)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/vault/accounts", query)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
 
 // GetVaultAccountsWithPageInfo Gets all assets that are currently supported by Fireblocks
 // Retrieves all vault accounts in your workspace. This endpoint returns a limited amount of results and quick response time.
 func (sdk *FireblocksSDK) GetVaultAccountsWithPageInfo(q *PagedVaultAccountsRequestFilters) (resp *PagedVaultAccountsResponse, err error) {
+	return sdk.GetVaultAccountsWithPageInfoWithContext(context.Background(), q)
+}
+
+// GetVaultAccountsWithPageInfoWithContext is GetVaultAccountsWithPageInfo with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetVaultAccountsWithPageInfoWithContext(ctx context.Context, q *PagedVaultAccountsRequestFilters) (resp *PagedVaultAccountsResponse, err error) {
 	query := BuildQuery(q).URLValues()
-	body, status, err := sdk.client.DoGetRequest("/vault/accounts_paged", query)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, "/vault/accounts_paged", query)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// NewVaultAccountsPager Iterates over all vault accounts matching q, following Paging.After until the last page.
+// q.Limit is the size of every page, q.After is the page to start from.
+func (sdk *FireblocksSDK) NewVaultAccountsPager(q *PagedVaultAccountsRequestFilters) *Pager[VaultAccountResponse] {
+	filters := PagedVaultAccountsRequestFilters{}
+	if q != nil {
+		filters = *q
+	}
+
+	fetch := func(ctx context.Context, cursor string) ([]VaultAccountResponse, string, error) {
+		page := filters
+		page.Before = ""
+		page.After = cursor
+
+		resp, err := sdk.GetVaultAccountsWithPageInfoWithContext(ctx, &page)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return resp.Accounts, resp.Paging.After, nil
 	}
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return NewPager(filters.After, fetch)
 }
 
 func (sdk *FireblocksSDK) GetVaultAccountsByID(vaultAccountID string) (resp *VaultAccountResponse, err error) {
-	body, status, err := sdk.client.DoGetRequest(fmt.Sprintf("/vault/accounts/%s", vaultAccountID), nil)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	return sdk.GetVaultAccountsByIDWithContext(context.Background(), vaultAccountID)
+}
 
-	return resp, errors.Wrap(err, "failed to make request")
+// GetVaultAccountsByIDWithContext is GetVaultAccountsByID with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetVaultAccountsByIDWithContext(ctx context.Context, vaultAccountID string) (resp *VaultAccountResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s", url.PathEscape(vaultAccountID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
 }
 
 // GetVaultAccountAsset Retrieves a wallet of a specific asset under a Fireblocks Vault Account.
 func (sdk *FireblocksSDK) GetVaultAccountAsset(vaultAccountID, assetID string) (resp *AssetResponse, err error) {
-	body, status, err := sdk.client.DoGetRequest(fmt.Sprintf("/vault/accounts/%s/%s", vaultAccountID, assetID), nil)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	return sdk.GetVaultAccountAssetWithContext(context.Background(), vaultAccountID, assetID)
+}
+
+// GetVaultAccountAssetWithContext is GetVaultAccountAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetVaultAccountAssetWithContext(ctx context.Context, vaultAccountID, assetID string) (resp *AssetResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/%s", url.PathEscape(vaultAccountID), url.PathEscape(assetID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
 
 // GetDepositAddresses Retrieves a wallet of a specific asset under a Fireblocks Vault Account.
 func (sdk *FireblocksSDK) GetDepositAddresses(vaultAccountID, assetID string) (resp []*DepositAddressResponse, err error) {
-	body, status, err := sdk.client.DoGetRequest(fmt.Sprintf("/vault/accounts/%s/%s/addresses", vaultAccountID, assetID), nil)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
-
-	return resp, errors.Wrap(err, "failed to make request")
+	return sdk.GetDepositAddressesWithContext(context.Background(), vaultAccountID, assetID)
 }
 
-// Response type supposed to be this - test it
+// GetDepositAddressesWithContext is GetDepositAddresses with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetDepositAddressesWithContext(ctx context.Context, vaultAccountID, assetID string) (resp []*DepositAddressResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/%s/addresses", url.PathEscape(vaultAccountID), url.PathEscape(assetID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
 
-/*
-{
-    "input": {
-        "txHash": "string",
-        "index": "number"
-    },
-    "address": "string",
-    "amount": "string",
-    "confirmations": "number",
-    "status": "string"
+	return resp, err
 }
-*/
 
 // GetUnspentInputs Returns unspent inputs of the requested asset in the Vault Account.
 // Gets utxo list for an asset in a vault account
 // vaultAccountId - The vault account ID
 // assetId - The ID of the asset for which to get the utxo list
-func (sdk *FireblocksSDK) GetUnspentInputs(vaultAccountID, assetID string) (resp []DepositAddressResponse, err error) {
-	body, status, err := sdk.client.DoGetRequest(fmt.Sprintf("/vault/accounts/%s/%s/unspent_inputs", vaultAccountID, assetID), nil)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+func (sdk *FireblocksSDK) GetUnspentInputs(vaultAccountID, assetID string) (resp []*UnspentInputsResponse, err error) {
+	return sdk.GetUnspentInputsWithContext(context.Background(), vaultAccountID, assetID)
+}
+
+// GetUnspentInputsWithContext is GetUnspentInputs with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetUnspentInputsWithContext(ctx context.Context, vaultAccountID, assetID string) (resp []*UnspentInputsResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/%s/unspent_inputs", url.PathEscape(vaultAccountID), url.PathEscape(assetID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
 
 // GetPublicKeyInfoForVaultAccount Get the public key information for a vault account
@@ -250,42 +271,57 @@ func (sdk *FireblocksSDK) GetPublicKeyInfoForVaultAccount(
 	change int,
 	addressIndex int,
 ) (resp *PublicKeyInfoResponse, err error) {
-	body, status, err := sdk.client.DoGetRequest(fmt.Sprintf(
+	return sdk.GetPublicKeyInfoForVaultAccountWithContext(context.Background(), vaultAccountID, assetID, change, addressIndex)
+}
+
+// GetPublicKeyInfoForVaultAccountWithContext is GetPublicKeyInfoForVaultAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetPublicKeyInfoForVaultAccountWithContext(
+	ctx context.Context,
+	vaultAccountID, assetID string,
+	change int,
+	addressIndex int,
+) (resp *PublicKeyInfoResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf(
 		"/vault/accounts/%s/%s/%v/%v/public_key_info",
-		vaultAccountID,
-		assetID,
+		url.PathEscape(vaultAccountID),
+		url.PathEscape(assetID),
 		change,
 		addressIndex,
 	), nil)
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
 
 func (sdk *FireblocksSDK) GenerateNewAddress(vaultAccountID, assetID, description, customerRefID string, opts ...func(*PostRequestOption)) (resp *GenerateAddressResponse, err error) {
+	return sdk.GenerateNewAddressWithContext(context.Background(), vaultAccountID, assetID, description, customerRefID, opts...)
+}
+
+// GenerateNewAddressWithContext is GenerateNewAddress with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GenerateNewAddressWithContext(ctx context.Context, vaultAccountID, assetID, description, customerRefID string, opts ...func(*PostRequestOption)) (resp *GenerateAddressResponse, err error) {
 	post := &PostOptions{
 		Description:   description,
 		CustomerRefID: customerRefID,
 	}
 
-	body, status, err := sdk.client.DoPostRequest(
-		fmt.Sprintf("/vault/accounts/%s/%s/addresses", vaultAccountID, assetID),
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/vault/accounts/%s/%s/addresses", url.PathEscape(vaultAccountID), url.PathEscape(assetID)),
 		post,
 		opts...,
 	)
 
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
 
 func (sdk *FireblocksSDK) CreateVaultAccount(name string, customerRefID string, hiddenOnUI *bool, autoFuel *bool, opts ...func(*PostRequestOption)) (resp VaultAccountResponse, err error) {
+	return sdk.CreateVaultAccountWithContext(context.Background(), name, customerRefID, hiddenOnUI, autoFuel, opts...)
+}
+
+// CreateVaultAccountWithContext is CreateVaultAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateVaultAccountWithContext(ctx context.Context, name string, customerRefID string, hiddenOnUI *bool, autoFuel *bool, opts ...func(*PostRequestOption)) (resp VaultAccountResponse, err error) {
 	post := &VaultAccountRequest{
 		Name:          name,
 		HiddenOnUI:    hiddenOnUI,
@@ -293,16 +329,174 @@ func (sdk *FireblocksSDK) CreateVaultAccount(name string, customerRefID string,
 		AutoFuel:      autoFuel,
 	}
 
-	body, status, err := sdk.client.DoPostRequest(
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
 		"/vault/accounts",
 		post,
 		opts...,
 	)
 
-	if err == nil && status == http.StatusOK {
-		err = json.Unmarshal(body, &resp)
-		return
-	}
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+type UpdateVaultAccountRequest struct {
+	Name string `json:"name"`
+}
+
+// RenameVaultAccountResponse defines model for RenameVaultAccountResponse.
+type RenameVaultAccountResponse struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type SetAutoFuelRequest struct {
+	AutoFuel bool `json:"autoFuel"`
+}
+
+type SetCustomerRefIDRequest struct {
+	CustomerRefID string `json:"customerRefId"`
+}
+
+// UpdateVaultAccount Renames the vault account
+func (sdk *FireblocksSDK) UpdateVaultAccount(vaultAccountID, name string, opts ...func(*PostRequestOption)) (resp *RenameVaultAccountResponse, err error) {
+	return sdk.UpdateVaultAccountWithContext(context.Background(), vaultAccountID, name, opts...)
+}
+
+// UpdateVaultAccountWithContext is UpdateVaultAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) UpdateVaultAccountWithContext(ctx context.Context, vaultAccountID, name string, opts ...func(*PostRequestOption)) (resp *RenameVaultAccountResponse, err error) {
+	body, status, err := sdk.client.DoPutRequestWithContext(
+		ctx,
+		fmt.Sprintf("/vault/accounts/%s", url.PathEscape(vaultAccountID)),
+		&UpdateVaultAccountRequest{Name: name},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// HideVaultAccount Hides the vault account from the web console view
+func (sdk *FireblocksSDK) HideVaultAccount(vaultAccountID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.HideVaultAccountWithContext(context.Background(), vaultAccountID, opts...)
+}
+
+// HideVaultAccountWithContext is HideVaultAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) HideVaultAccountWithContext(ctx context.Context, vaultAccountID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/hide", url.PathEscape(vaultAccountID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// UnhideVaultAccount Returns the hidden vault account to the web console view
+func (sdk *FireblocksSDK) UnhideVaultAccount(vaultAccountID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.UnhideVaultAccountWithContext(context.Background(), vaultAccountID, opts...)
+}
+
+// UnhideVaultAccountWithContext is UnhideVaultAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) UnhideVaultAccountWithContext(ctx context.Context, vaultAccountID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/unhide", url.PathEscape(vaultAccountID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// SetAutoFuel Turns on or off Gas Station fueling of the vault account
+func (sdk *FireblocksSDK) SetAutoFuel(vaultAccountID string, autoFuel bool, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetAutoFuelWithContext(context.Background(), vaultAccountID, autoFuel, opts...)
+}
+
+// SetAutoFuelWithContext is SetAutoFuel with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetAutoFuelWithContext(ctx context.Context, vaultAccountID string, autoFuel bool, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/vault/accounts/%s/set_auto_fuel", url.PathEscape(vaultAccountID)),
+		&SetAutoFuelRequest{AutoFuel: autoFuel},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// SetCustomerRefIDForVaultAccount Sets the ID for AML providers to associate the owner of funds with transactions,
+// the empty customerRefID removes it
+func (sdk *FireblocksSDK) SetCustomerRefIDForVaultAccount(vaultAccountID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetCustomerRefIDForVaultAccountWithContext(context.Background(), vaultAccountID, customerRefID, opts...)
+}
+
+// SetCustomerRefIDForVaultAccountWithContext is SetCustomerRefIDForVaultAccount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetCustomerRefIDForVaultAccountWithContext(ctx context.Context, vaultAccountID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/vault/accounts/%s/set_customer_ref_id", url.PathEscape(vaultAccountID)),
+		&SetCustomerRefIDRequest{CustomerRefID: customerRefID},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// CreateVaultAsset Creates a wallet of the asset in the vault account
+// eosAccountName is used only for EOS and may be empty
+func (sdk *FireblocksSDK) CreateVaultAsset(vaultAccountID, assetID, eosAccountName string, opts ...func(*PostRequestOption)) (resp *CreateVaultAssetResponse, err error) {
+	return sdk.CreateVaultAssetWithContext(context.Background(), vaultAccountID, assetID, eosAccountName, opts...)
+}
+
+// CreateVaultAssetWithContext is CreateVaultAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateVaultAssetWithContext(ctx context.Context, vaultAccountID, assetID, eosAccountName string, opts ...func(*PostRequestOption)) (resp *CreateVaultAssetResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("/vault/accounts/%s/%s", url.PathEscape(vaultAccountID), url.PathEscape(assetID)),
+		&CreateVaultAssetRequest{EosAccountName: eosAccountName},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// ActivateVaultAsset Activates the wallet of the asset which requires activation, e.g. XLM or ALGO tokens
+func (sdk *FireblocksSDK) ActivateVaultAsset(vaultAccountID, assetID string, opts ...func(*PostRequestOption)) (resp *CreateVaultAssetResponse, err error) {
+	return sdk.ActivateVaultAssetWithContext(context.Background(), vaultAccountID, assetID, opts...)
+}
+
+// ActivateVaultAssetWithContext is ActivateVaultAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) ActivateVaultAssetWithContext(ctx context.Context, vaultAccountID, assetID string, opts ...func(*PostRequestOption)) (resp *CreateVaultAssetResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/%s/activate", url.PathEscape(vaultAccountID), url.PathEscape(assetID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// GetMaxSpendableAmount Gets the maximum amount which can be spent from the wallet in a single transaction
+// manualSigning is true for the amount of transactions signed manually, they may use more inputs
+func (sdk *FireblocksSDK) GetMaxSpendableAmount(vaultAccountID, assetID string, manualSigning bool) (resp *MaxSpendableAmountResponse, err error) {
+	return sdk.GetMaxSpendableAmountWithContext(context.Background(), vaultAccountID, assetID, manualSigning)
+}
+
+// GetMaxSpendableAmountWithContext is GetMaxSpendableAmount with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetMaxSpendableAmountWithContext(ctx context.Context, vaultAccountID, assetID string, manualSigning bool) (resp *MaxSpendableAmountResponse, err error) {
+	query := url.Values{"manualSigning": {strconv.FormatBool(manualSigning)}}
+
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/%s/max_spendable_amount", url.PathEscape(vaultAccountID), url.PathEscape(assetID)), query)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+// RefreshVaultAssetBalance Updates the balance of the wallet from the blockchain and returns it
+func (sdk *FireblocksSDK) RefreshVaultAssetBalance(vaultAccountID, assetID string, opts ...func(*PostRequestOption)) (resp *AssetResponse, err error) {
+	return sdk.RefreshVaultAssetBalanceWithContext(context.Background(), vaultAccountID, assetID, opts...)
+}
+
+// RefreshVaultAssetBalanceWithContext is RefreshVaultAssetBalance with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) RefreshVaultAssetBalanceWithContext(ctx context.Context, vaultAccountID, assetID string, opts ...func(*PostRequestOption)) (resp *AssetResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("/vault/accounts/%s/%s/balance", url.PathEscape(vaultAccountID), url.PathEscape(assetID)), nil, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
 
-	return resp, errors.Wrap(err, "failed to make request")
+	return resp, err
 }
@@ -0,0 +1,177 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestVaultSuite(t *testing.T) {
+	suite.Run(t, new(VaultSuite))
+}
+
+type VaultSuite struct {
+	suite.Suite
+	srv       *fireblockstest.Server
+	sdk       *sdk.FireblocksSDK
+	accountID string
+}
+
+func (suite *VaultSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+	suite.accountID = suite.srv.AddVaultAccount("vault")
+}
+
+func (suite *VaultSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *VaultSuite) account() *sdk.VaultAccountResponse {
+	account, err := suite.sdk.GetVaultAccountsByID(suite.accountID)
+	require.NoError(suite.T(), err)
+
+	return account
+}
+
+func (suite *VaultSuite) TestUpdateVaultAccount() {
+	resp, err := suite.sdk.UpdateVaultAccount(suite.accountID, "treasury", sdk.WithIdempotencyKey("rename-1"))
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), &sdk.RenameVaultAccountResponse{ID: suite.accountID, Name: "treasury"}, resp)
+
+	requests := suite.srv.Requests()
+	require.Equal(suite.T(), "rename-1", requests[len(requests)-1].Header.Get("Idempotency-Key"))
+	require.Equal(suite.T(), "treasury", suite.account().Name)
+
+	_, err = suite.sdk.UpdateVaultAccount(suite.accountID, "")
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	_, err = suite.sdk.UpdateVaultAccount("42", "treasury")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *VaultSuite) TestHideAndUnhideVaultAccount() {
+	resp, err := suite.sdk.HideVaultAccount(suite.accountID)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+	require.True(suite.T(), *suite.account().HiddenOnUI)
+
+	resp, err = suite.sdk.UnhideVaultAccount(suite.accountID)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+	require.False(suite.T(), *suite.account().HiddenOnUI)
+
+	_, err = suite.sdk.HideVaultAccount("42")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *VaultSuite) TestSetAutoFuel() {
+	_, err := suite.sdk.SetAutoFuel(suite.accountID, true)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), *suite.account().AutoFuel)
+
+	_, err = suite.sdk.SetAutoFuel(suite.accountID, false)
+	require.NoError(suite.T(), err)
+	require.False(suite.T(), *suite.account().AutoFuel)
+}
+
+func (suite *VaultSuite) TestSetCustomerRefID() {
+	_, err := suite.sdk.SetCustomerRefIDForVaultAccount(suite.accountID, "customer-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "customer-1", *suite.account().CustomerRefID)
+
+	_, err = suite.sdk.SetCustomerRefIDForVaultAccount(suite.accountID, "")
+	require.NoError(suite.T(), err)
+	require.Nil(suite.T(), suite.account().CustomerRefID)
+}
+
+func (suite *VaultSuite) TestIdempotencyKeyAppliesOnce() {
+	_, err := suite.sdk.SetAutoFuel(suite.accountID, true, sdk.WithIdempotencyKey("auto-fuel-1"))
+	require.NoError(suite.T(), err)
+
+	_, err = suite.sdk.SetAutoFuel(suite.accountID, false)
+	require.NoError(suite.T(), err)
+
+	// the replayed key is answered from the first response and does not turn auto fuel on again
+	resp, err := suite.sdk.SetAutoFuel(suite.accountID, true, sdk.WithIdempotencyKey("auto-fuel-1"))
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), resp.Success)
+	require.False(suite.T(), *suite.account().AutoFuel)
+}
+
+func (suite *VaultSuite) TestCreateVaultAsset() {
+	resp, err := suite.sdk.CreateVaultAsset(suite.accountID, "BTC_TEST", "")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "BTC_TEST", resp.ID)
+	require.NotEmpty(suite.T(), resp.Address)
+
+	asset, err := suite.sdk.GetVaultAccountAsset(suite.accountID, "BTC_TEST")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "0", asset.Total)
+
+	addresses, err := suite.sdk.GetDepositAddresses(suite.accountID, "BTC_TEST")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), resp.Address, addresses[0].Address)
+
+	_, err = suite.sdk.CreateVaultAsset(suite.accountID, "BTC_TEST", "")
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	_, err = suite.sdk.CreateVaultAsset("42", "BTC_TEST", "")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *VaultSuite) TestCreateUnsupportedVaultAsset() {
+	suite.srv.AddSupportedAsset(sdk.AssetTypeResponse{ID: "BTC_TEST"})
+
+	_, err := suite.sdk.CreateVaultAsset(suite.accountID, "DOGE_TEST", "")
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	var apiErr *sdk.APIError
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), "CREATE_VAULT_ASSET_UNSUPPORTED_ERROR", apiErr.TextCode)
+}
+
+func (suite *VaultSuite) TestActivateVaultAsset() {
+	_, err := suite.sdk.CreateVaultAsset(suite.accountID, "XLM_USDC_T_CEKS", "")
+	require.NoError(suite.T(), err)
+
+	resp, err := suite.sdk.ActivateVaultAsset(suite.accountID, "XLM_USDC_T_CEKS")
+	require.NoError(suite.T(), err)
+	require.NotEmpty(suite.T(), resp.ActivationTxID)
+
+	tx, err := suite.sdk.GetTransactionByID(resp.ActivationTxID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "XLM_USDC_T_CEKS", tx.AssetID)
+
+	_, err = suite.sdk.ActivateVaultAsset(suite.accountID, "ETH_TEST")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *VaultSuite) TestGetMaxSpendableAmount() {
+	require.NoError(suite.T(), suite.srv.SetAssetBalance(suite.accountID, "BTC_TEST", "1.25"))
+
+	resp, err := suite.sdk.GetMaxSpendableAmount(suite.accountID, "BTC_TEST", true)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "1.25", resp.MaxSpendableAmount)
+
+	requests := suite.srv.Requests()
+	require.Equal(suite.T(), "true", requests[len(requests)-1].URL.Query().Get("manualSigning"))
+
+	_, err = suite.sdk.GetMaxSpendableAmount(suite.accountID, "ETH_TEST", false)
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *VaultSuite) TestRefreshVaultAssetBalance() {
+	require.NoError(suite.T(), suite.srv.SetAssetBalance(suite.accountID, "BTC_TEST", "3"))
+
+	asset, err := suite.sdk.RefreshVaultAssetBalance(suite.accountID, "BTC_TEST")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "3", asset.Total)
+}
@@ -0,0 +1,402 @@
+package fireblocksdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Wallets endpoints: internal wallets, external wallets and contracts of the whitelisted address book
+
+const (
+	internalWalletsPath = "/internal_wallets"
+	externalWalletsPath = "/external_wallets"
+	contractsPath       = "/contracts"
+)
+
+type WalletAssetStatus string
+
+const (
+	WalletAssetStatusWaitingForApproval WalletAssetStatus = "WAITING_FOR_APPROVAL"
+	WalletAssetStatusApproved           WalletAssetStatus = "APPROVED"
+	WalletAssetStatusCancelled          WalletAssetStatus = "CANCELLED"
+	WalletAssetStatusRejected           WalletAssetStatus = "REJECTED"
+	WalletAssetStatusFailed             WalletAssetStatus = "FAILED"
+)
+
+// Responses
+
+/*
+export interface WalletContainerResponse<WalletAssetType> {
+    id: string;
+    name: string;
+    assets: WalletAssetType[];
+    customerRefId?: string;
+}
+*/
+
+// WalletAssetResponse defines model for the whitelisted address of the wallet.
+type WalletAssetResponse struct {
+	ID             string            `json:"id,omitempty"`
+	Balance        string            `json:"balance,omitempty"` // Only for internal wallets
+	LockedAmount   string            `json:"lockedAmount,omitempty"`
+	Status         WalletAssetStatus `json:"status,omitempty"`
+	Address        string            `json:"address,omitempty"`
+	Tag            string            `json:"tag,omitempty"`            // Destination tag for XRP, memo for EOS/XLM
+	ActivationTime string            `json:"activationTime,omitempty"` // Time when the address becomes usable after approval
+}
+
+// WalletResponse defines model for internal and external wallets and contracts.
+type WalletResponse struct {
+	ID            string                 `json:"id,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Assets        []*WalletAssetResponse `json:"assets,omitempty"`
+	CustomerRefID string                 `json:"customerRefId,omitempty"` // Not supported by contracts
+}
+
+// Requests
+
+type CreateWalletRequest struct {
+	Name          string `json:"name"`
+	CustomerRefID string `json:"customerRefId,omitempty"`
+}
+
+type WalletAssetRequest struct {
+	Address string `json:"address"`
+	Tag     string `json:"tag,omitempty"`
+}
+
+// Destinations
+
+// VaultAccountPeer points at the vault account
+func VaultAccountPeer(vaultAccountID string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeVaultAccount, ID: vaultAccountID}
+}
+
+// InternalWalletPeer points at the internal wallet, the asset of the transaction selects its address
+func InternalWalletPeer(walletID string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeInternalWallet, ID: walletID}
+}
+
+// ExternalWalletPeer points at the external wallet, the asset of the transaction selects its address
+func ExternalWalletPeer(walletID string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeExternalWallet, ID: walletID}
+}
+
+// ContractPeer points at the whitelisted contract
+func ContractPeer(contractID string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeContract, ID: contractID}
+}
+
+// ExchangeAccountPeer points at the exchange account
+func ExchangeAccountPeer(exchangeAccountID string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeExchangeAccount, ID: exchangeAccountID}
+}
+
+// FiatAccountPeer points at the fiat account
+func FiatAccountPeer(fiatAccountID string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeFiatAccount, ID: fiatAccountID}
+}
+
+// OneTimeAddressPeer points at the address which is not whitelisted, tag is optional
+func OneTimeAddressPeer(address, tag string) *TransferPeerPath {
+	return &TransferPeerPath{Type: PeerTypeOneTimeAddress, OneTimeAddress: &OneTimeAddress{Address: address, Tag: tag}}
+}
+
+// Shared implementation, prefix is one of internalWalletsPath, externalWalletsPath and contractsPath
+
+func (sdk *FireblocksSDK) getWallets(ctx context.Context, prefix string) (resp []*WalletResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, prefix, nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+func (sdk *FireblocksSDK) getWallet(ctx context.Context, prefix, walletID string) (resp *WalletResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("%s/%s", prefix, url.PathEscape(walletID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+func (sdk *FireblocksSDK) createWallet(ctx context.Context, prefix string, req *CreateWalletRequest, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, prefix, req, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+func (sdk *FireblocksSDK) deleteWallet(ctx context.Context, prefix, walletID string) error {
+	body, status, err := sdk.client.DoDeleteRequestWithContext(ctx, fmt.Sprintf("%s/%s", prefix, url.PathEscape(walletID)))
+
+	return handleResponse(body, status, err, &OperationSuccessResponse{}, http.StatusOK, http.StatusNoContent)
+}
+
+func (sdk *FireblocksSDK) getWalletAsset(ctx context.Context, prefix, walletID, assetID string) (resp *WalletAssetResponse, err error) {
+	body, status, err := sdk.client.DoGetRequestWithContext(ctx, fmt.Sprintf("%s/%s/%s", prefix, url.PathEscape(walletID), url.PathEscape(assetID)), nil)
+	err = handleResponse(body, status, err, &resp, http.StatusOK)
+
+	return resp, err
+}
+
+func (sdk *FireblocksSDK) addWalletAsset(ctx context.Context, prefix, walletID, assetID string, req *WalletAssetRequest, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(ctx, fmt.Sprintf("%s/%s/%s", prefix, url.PathEscape(walletID), url.PathEscape(assetID)), req, opts...)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+func (sdk *FireblocksSDK) deleteWalletAsset(ctx context.Context, prefix, walletID, assetID string) error {
+	body, status, err := sdk.client.DoDeleteRequestWithContext(ctx, fmt.Sprintf("%s/%s/%s", prefix, url.PathEscape(walletID), url.PathEscape(assetID)))
+
+	return handleResponse(body, status, err, &OperationSuccessResponse{}, http.StatusOK, http.StatusNoContent)
+}
+
+func (sdk *FireblocksSDK) setWalletCustomerRefID(ctx context.Context, prefix, walletID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	body, status, err := sdk.client.DoPostRequestWithContext(
+		ctx,
+		fmt.Sprintf("%s/%s/set_customer_ref_id", prefix, url.PathEscape(walletID)),
+		&SetCustomerRefIDRequest{CustomerRefID: customerRefID},
+		opts...,
+	)
+	err = handleResponse(body, status, err, &resp, http.StatusOK, http.StatusCreated)
+
+	return resp, err
+}
+
+// Internal wallets
+
+// GetInternalWallets Gets all internal wallets, whitelisted addresses owned by the workspace
+func (sdk *FireblocksSDK) GetInternalWallets() (resp []*WalletResponse, err error) {
+	return sdk.GetInternalWalletsWithContext(context.Background())
+}
+
+// GetInternalWalletsWithContext is GetInternalWallets with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetInternalWalletsWithContext(ctx context.Context) (resp []*WalletResponse, err error) {
+	return sdk.getWallets(ctx, internalWalletsPath)
+}
+
+// GetInternalWallet Gets the internal wallet with its assets
+func (sdk *FireblocksSDK) GetInternalWallet(walletID string) (resp *WalletResponse, err error) {
+	return sdk.GetInternalWalletWithContext(context.Background(), walletID)
+}
+
+// GetInternalWalletWithContext is GetInternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetInternalWalletWithContext(ctx context.Context, walletID string) (resp *WalletResponse, err error) {
+	return sdk.getWallet(ctx, internalWalletsPath, walletID)
+}
+
+// CreateInternalWallet Creates the internal wallet without assets
+func (sdk *FireblocksSDK) CreateInternalWallet(name, customerRefID string, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	return sdk.CreateInternalWalletWithContext(context.Background(), name, customerRefID, opts...)
+}
+
+// CreateInternalWalletWithContext is CreateInternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateInternalWalletWithContext(ctx context.Context, name, customerRefID string, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	return sdk.createWallet(ctx, internalWalletsPath, &CreateWalletRequest{Name: name, CustomerRefID: customerRefID}, opts...)
+}
+
+// DeleteInternalWallet Deletes the internal wallet with all its assets
+func (sdk *FireblocksSDK) DeleteInternalWallet(walletID string) error {
+	return sdk.DeleteInternalWalletWithContext(context.Background(), walletID)
+}
+
+// DeleteInternalWalletWithContext is DeleteInternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteInternalWalletWithContext(ctx context.Context, walletID string) error {
+	return sdk.deleteWallet(ctx, internalWalletsPath, walletID)
+}
+
+// GetInternalWalletAsset Gets the address and balance of the asset in the internal wallet
+func (sdk *FireblocksSDK) GetInternalWalletAsset(walletID, assetID string) (resp *WalletAssetResponse, err error) {
+	return sdk.GetInternalWalletAssetWithContext(context.Background(), walletID, assetID)
+}
+
+// GetInternalWalletAssetWithContext is GetInternalWalletAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetInternalWalletAssetWithContext(ctx context.Context, walletID, assetID string) (resp *WalletAssetResponse, err error) {
+	return sdk.getWalletAsset(ctx, internalWalletsPath, walletID, assetID)
+}
+
+// AddInternalWalletAsset Whitelists the address of the asset in the internal wallet, tag is optional
+func (sdk *FireblocksSDK) AddInternalWalletAsset(walletID, assetID, address, tag string, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	return sdk.AddInternalWalletAssetWithContext(context.Background(), walletID, assetID, address, tag, opts...)
+}
+
+// AddInternalWalletAssetWithContext is AddInternalWalletAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) AddInternalWalletAssetWithContext(ctx context.Context, walletID, assetID, address, tag string, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	return sdk.addWalletAsset(ctx, internalWalletsPath, walletID, assetID, &WalletAssetRequest{Address: address, Tag: tag}, opts...)
+}
+
+// DeleteInternalWalletAsset Removes the asset from the internal wallet
+func (sdk *FireblocksSDK) DeleteInternalWalletAsset(walletID, assetID string) error {
+	return sdk.DeleteInternalWalletAssetWithContext(context.Background(), walletID, assetID)
+}
+
+// DeleteInternalWalletAssetWithContext is DeleteInternalWalletAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteInternalWalletAssetWithContext(ctx context.Context, walletID, assetID string) error {
+	return sdk.deleteWalletAsset(ctx, internalWalletsPath, walletID, assetID)
+}
+
+// SetCustomerRefIDForInternalWallet Sets the ID for AML providers of the internal wallet, the empty customerRefID removes it
+func (sdk *FireblocksSDK) SetCustomerRefIDForInternalWallet(walletID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetCustomerRefIDForInternalWalletWithContext(context.Background(), walletID, customerRefID, opts...)
+}
+
+// SetCustomerRefIDForInternalWalletWithContext is SetCustomerRefIDForInternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetCustomerRefIDForInternalWalletWithContext(ctx context.Context, walletID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.setWalletCustomerRefID(ctx, internalWalletsPath, walletID, customerRefID, opts...)
+}
+
+// External wallets
+
+// GetExternalWallets Gets all external wallets, whitelisted addresses of counterparties
+func (sdk *FireblocksSDK) GetExternalWallets() (resp []*WalletResponse, err error) {
+	return sdk.GetExternalWalletsWithContext(context.Background())
+}
+
+// GetExternalWalletsWithContext is GetExternalWallets with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetExternalWalletsWithContext(ctx context.Context) (resp []*WalletResponse, err error) {
+	return sdk.getWallets(ctx, externalWalletsPath)
+}
+
+// GetExternalWallet Gets the external wallet with its assets
+func (sdk *FireblocksSDK) GetExternalWallet(walletID string) (resp *WalletResponse, err error) {
+	return sdk.GetExternalWalletWithContext(context.Background(), walletID)
+}
+
+// GetExternalWalletWithContext is GetExternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetExternalWalletWithContext(ctx context.Context, walletID string) (resp *WalletResponse, err error) {
+	return sdk.getWallet(ctx, externalWalletsPath, walletID)
+}
+
+// CreateExternalWallet Creates the external wallet without assets
+func (sdk *FireblocksSDK) CreateExternalWallet(name, customerRefID string, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	return sdk.CreateExternalWalletWithContext(context.Background(), name, customerRefID, opts...)
+}
+
+// CreateExternalWalletWithContext is CreateExternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateExternalWalletWithContext(ctx context.Context, name, customerRefID string, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	return sdk.createWallet(ctx, externalWalletsPath, &CreateWalletRequest{Name: name, CustomerRefID: customerRefID}, opts...)
+}
+
+// DeleteExternalWallet Deletes the external wallet with all its assets
+func (sdk *FireblocksSDK) DeleteExternalWallet(walletID string) error {
+	return sdk.DeleteExternalWalletWithContext(context.Background(), walletID)
+}
+
+// DeleteExternalWalletWithContext is DeleteExternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteExternalWalletWithContext(ctx context.Context, walletID string) error {
+	return sdk.deleteWallet(ctx, externalWalletsPath, walletID)
+}
+
+// GetExternalWalletAsset Gets the address of the asset in the external wallet
+func (sdk *FireblocksSDK) GetExternalWalletAsset(walletID, assetID string) (resp *WalletAssetResponse, err error) {
+	return sdk.GetExternalWalletAssetWithContext(context.Background(), walletID, assetID)
+}
+
+// GetExternalWalletAssetWithContext is GetExternalWalletAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetExternalWalletAssetWithContext(ctx context.Context, walletID, assetID string) (resp *WalletAssetResponse, err error) {
+	return sdk.getWalletAsset(ctx, externalWalletsPath, walletID, assetID)
+}
+
+// AddExternalWalletAsset Whitelists the address of the asset in the external wallet, tag is optional
+func (sdk *FireblocksSDK) AddExternalWalletAsset(walletID, assetID, address, tag string, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	return sdk.AddExternalWalletAssetWithContext(context.Background(), walletID, assetID, address, tag, opts...)
+}
+
+// AddExternalWalletAssetWithContext is AddExternalWalletAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) AddExternalWalletAssetWithContext(ctx context.Context, walletID, assetID, address, tag string, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	return sdk.addWalletAsset(ctx, externalWalletsPath, walletID, assetID, &WalletAssetRequest{Address: address, Tag: tag}, opts...)
+}
+
+// DeleteExternalWalletAsset Removes the asset from the external wallet
+func (sdk *FireblocksSDK) DeleteExternalWalletAsset(walletID, assetID string) error {
+	return sdk.DeleteExternalWalletAssetWithContext(context.Background(), walletID, assetID)
+}
+
+// DeleteExternalWalletAssetWithContext is DeleteExternalWalletAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteExternalWalletAssetWithContext(ctx context.Context, walletID, assetID string) error {
+	return sdk.deleteWalletAsset(ctx, externalWalletsPath, walletID, assetID)
+}
+
+// SetCustomerRefIDForExternalWallet Sets the ID for AML providers of the external wallet, the empty customerRefID removes it
+func (sdk *FireblocksSDK) SetCustomerRefIDForExternalWallet(walletID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.SetCustomerRefIDForExternalWalletWithContext(context.Background(), walletID, customerRefID, opts...)
+}
+
+// SetCustomerRefIDForExternalWalletWithContext is SetCustomerRefIDForExternalWallet with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) SetCustomerRefIDForExternalWalletWithContext(ctx context.Context, walletID, customerRefID string, opts ...func(*PostRequestOption)) (resp *OperationSuccessResponse, err error) {
+	return sdk.setWalletCustomerRefID(ctx, externalWalletsPath, walletID, customerRefID, opts...)
+}
+
+// Contracts
+
+// GetContracts Gets all whitelisted contracts
+func (sdk *FireblocksSDK) GetContracts() (resp []*WalletResponse, err error) {
+	return sdk.GetContractsWithContext(context.Background())
+}
+
+// GetContractsWithContext is GetContracts with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetContractsWithContext(ctx context.Context) (resp []*WalletResponse, err error) {
+	return sdk.getWallets(ctx, contractsPath)
+}
+
+// GetContract Gets the contract with its assets
+func (sdk *FireblocksSDK) GetContract(contractID string) (resp *WalletResponse, err error) {
+	return sdk.GetContractWithContext(context.Background(), contractID)
+}
+
+// GetContractWithContext is GetContract with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetContractWithContext(ctx context.Context, contractID string) (resp *WalletResponse, err error) {
+	return sdk.getWallet(ctx, contractsPath, contractID)
+}
+
+// CreateContract Creates the contract without assets
+func (sdk *FireblocksSDK) CreateContract(name string, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	return sdk.CreateContractWithContext(context.Background(), name, opts...)
+}
+
+// CreateContractWithContext is CreateContract with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) CreateContractWithContext(ctx context.Context, name string, opts ...func(*PostRequestOption)) (resp *WalletResponse, err error) {
+	return sdk.createWallet(ctx, contractsPath, &CreateWalletRequest{Name: name}, opts...)
+}
+
+// DeleteContract Deletes the contract with all its assets
+func (sdk *FireblocksSDK) DeleteContract(contractID string) error {
+	return sdk.DeleteContractWithContext(context.Background(), contractID)
+}
+
+// DeleteContractWithContext is DeleteContract with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteContractWithContext(ctx context.Context, contractID string) error {
+	return sdk.deleteWallet(ctx, contractsPath, contractID)
+}
+
+// GetContractAsset Gets the address of the asset in the contract
+func (sdk *FireblocksSDK) GetContractAsset(contractID, assetID string) (resp *WalletAssetResponse, err error) {
+	return sdk.GetContractAssetWithContext(context.Background(), contractID, assetID)
+}
+
+// GetContractAssetWithContext is GetContractAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) GetContractAssetWithContext(ctx context.Context, contractID, assetID string) (resp *WalletAssetResponse, err error) {
+	return sdk.getWalletAsset(ctx, contractsPath, contractID, assetID)
+}
+
+// AddContractAsset Whitelists the address of the asset in the contract, tag is optional
+func (sdk *FireblocksSDK) AddContractAsset(contractID, assetID, address, tag string, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	return sdk.AddContractAssetWithContext(context.Background(), contractID, assetID, address, tag, opts...)
+}
+
+// AddContractAssetWithContext is AddContractAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) AddContractAssetWithContext(ctx context.Context, contractID, assetID, address, tag string, opts ...func(*PostRequestOption)) (resp *WalletAssetResponse, err error) {
+	return sdk.addWalletAsset(ctx, contractsPath, contractID, assetID, &WalletAssetRequest{Address: address, Tag: tag}, opts...)
+}
+
+// DeleteContractAsset Removes the asset from the contract
+func (sdk *FireblocksSDK) DeleteContractAsset(contractID, assetID string) error {
+	return sdk.DeleteContractAssetWithContext(context.Background(), contractID, assetID)
+}
+
+// DeleteContractAssetWithContext is DeleteContractAsset with ctx for cancellation and deadlines
+func (sdk *FireblocksSDK) DeleteContractAssetWithContext(ctx context.Context, contractID, assetID string) error {
+	return sdk.deleteWalletAsset(ctx, contractsPath, contractID, assetID)
+}
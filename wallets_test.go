@@ -0,0 +1,151 @@
+package fireblocksdk_test
+
+import (
+	sdk "fireblocksdk"
+	"fireblocksdk/fireblockstest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWalletsSuite(t *testing.T) {
+	suite.Run(t, new(WalletsSuite))
+}
+
+type WalletsSuite struct {
+	suite.Suite
+	srv *fireblockstest.Server
+	sdk *sdk.FireblocksSDK
+}
+
+func (suite *WalletsSuite) SetupTest() {
+	suite.srv = fireblockstest.NewServer()
+
+	fb, err := sdk.CreateSDK(suite.srv.APIKey, suite.srv.PrivateKeyPEM(), suite.srv.URL)
+	require.NoError(suite.T(), err)
+
+	suite.sdk = fb
+}
+
+func (suite *WalletsSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *WalletsSuite) TestInternalWallets() {
+	wallet, err := suite.sdk.CreateInternalWallet("cold storage", "ref-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "ref-1", wallet.CustomerRefID)
+
+	asset, err := suite.sdk.AddInternalWalletAsset(wallet.ID, "BTC_TEST", "tb1qcold", "")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.WalletAssetStatusApproved, asset.Status)
+	require.Equal(suite.T(), "0", asset.Balance)
+
+	_, err = suite.sdk.AddInternalWalletAsset(wallet.ID, "BTC_TEST", "tb1qother", "")
+	require.ErrorIs(suite.T(), err, sdk.ErrValidation)
+
+	_, err = suite.sdk.SetCustomerRefIDForInternalWallet(wallet.ID, "ref-2")
+	require.NoError(suite.T(), err)
+
+	wallets, err := suite.sdk.GetInternalWallets()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), wallets, 1)
+	require.Equal(suite.T(), "ref-2", wallets[0].CustomerRefID)
+	require.Len(suite.T(), wallets[0].Assets, 1)
+
+	require.NoError(suite.T(), suite.sdk.DeleteInternalWalletAsset(wallet.ID, "BTC_TEST"))
+
+	_, err = suite.sdk.GetInternalWalletAsset(wallet.ID, "BTC_TEST")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+
+	require.NoError(suite.T(), suite.sdk.DeleteInternalWallet(wallet.ID))
+
+	_, err = suite.sdk.GetInternalWallet(wallet.ID)
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *WalletsSuite) TestExternalWallets() {
+	wallet, err := suite.sdk.CreateExternalWallet("counterparty", "")
+	require.NoError(suite.T(), err)
+
+	_, err = suite.sdk.AddExternalWalletAsset(wallet.ID, "XRP_TEST", "rCounterparty", "12345")
+	require.NoError(suite.T(), err)
+
+	asset, err := suite.sdk.GetExternalWalletAsset(wallet.ID, "XRP_TEST")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "rCounterparty", asset.Address)
+	require.Equal(suite.T(), "12345", asset.Tag)
+	require.Empty(suite.T(), asset.Balance)
+
+	got, err := suite.sdk.GetExternalWallet(wallet.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "counterparty", got.Name)
+
+	// internal and external wallets do not share IDs
+	_, err = suite.sdk.GetInternalWallet(wallet.ID)
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+
+	require.NoError(suite.T(), suite.sdk.DeleteExternalWallet(wallet.ID))
+	require.ErrorIs(suite.T(), suite.sdk.DeleteExternalWallet(wallet.ID), sdk.ErrNotFound)
+}
+
+func (suite *WalletsSuite) TestContracts() {
+	contract, err := suite.sdk.CreateContract("pool")
+	require.NoError(suite.T(), err)
+
+	_, err = suite.sdk.AddContractAsset(contract.ID, "ETH_TEST", "0xpool", "")
+	require.NoError(suite.T(), err)
+
+	contracts, err := suite.sdk.GetContracts()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), contracts, 1)
+	require.Equal(suite.T(), "0xpool", contracts[0].Assets[0].Address)
+
+	got, err := suite.sdk.GetContract(contract.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "pool", got.Name)
+
+	require.NoError(suite.T(), suite.sdk.DeleteContractAsset(contract.ID, "ETH_TEST"))
+
+	_, err = suite.sdk.GetContractAsset(contract.ID, "ETH_TEST")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+
+	require.NoError(suite.T(), suite.sdk.DeleteContract(contract.ID))
+}
+
+func (suite *WalletsSuite) TestTransferToWallet() {
+	accountID := suite.srv.AddVaultAccount("vault")
+
+	wallet, err := suite.sdk.CreateExternalWallet("counterparty", "")
+	require.NoError(suite.T(), err)
+
+	_, err = suite.sdk.AddExternalWalletAsset(wallet.ID, "XRP_TEST", "rCounterparty", "12345")
+	require.NoError(suite.T(), err)
+
+	created, err := suite.sdk.CreateTransaction(&sdk.TransactionRequest{
+		AssetID:     "XRP_TEST",
+		Source:      sdk.VaultAccountPeer(accountID),
+		Destination: sdk.ExternalWalletPeer(wallet.ID),
+		Amount:      "10",
+	})
+	require.NoError(suite.T(), err)
+
+	tx, err := suite.sdk.GetTransactionByID(created.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.PeerTypeExternalWallet, tx.Destination.Type)
+	require.Equal(suite.T(), wallet.ID, tx.Destination.ID)
+	require.Equal(suite.T(), "rCounterparty", tx.DestinationAddress)
+	require.Equal(suite.T(), "12345", tx.DestinationTag)
+}
+
+func TestTransferPeerPaths(t *testing.T) {
+	require.Equal(t, &sdk.TransferPeerPath{Type: sdk.PeerTypeInternalWallet, ID: "w"}, sdk.InternalWalletPeer("w"))
+	require.Equal(t, &sdk.TransferPeerPath{Type: sdk.PeerTypeContract, ID: "c"}, sdk.ContractPeer("c"))
+	require.Equal(t, &sdk.TransferPeerPath{Type: sdk.PeerTypeExchangeAccount, ID: "e"}, sdk.ExchangeAccountPeer("e"))
+	require.Equal(t, &sdk.TransferPeerPath{Type: sdk.PeerTypeFiatAccount, ID: "f"}, sdk.FiatAccountPeer("f"))
+	require.Equal(t,
+		&sdk.TransferPeerPath{Type: sdk.PeerTypeOneTimeAddress, OneTimeAddress: &sdk.OneTimeAddress{Address: "r", Tag: "1"}},
+		sdk.OneTimeAddressPeer("r", "1"),
+	)
+}
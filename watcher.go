@@ -0,0 +1,282 @@
+package fireblocksdk
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Transaction watcher
+
+// TransactionStatusChange is reported every time status or sub status of a watched transaction changes
+type TransactionStatusChange struct {
+	TxID              string
+	PreviousStatus    TransactionStatus // Empty for the first observed status
+	Status            TransactionStatus
+	PreviousSubStatus string
+	SubStatus         string
+	Transaction       *TransactionResponse
+}
+
+type WatchOptions struct {
+	pollInterval time.Duration
+	maxInterval  time.Duration
+	backoff      float64
+	jitter       float64
+	onChange     func(TransactionStatusChange)
+	changes      chan<- TransactionStatusChange
+}
+
+// DefaultWatchOptions polls every second and slows down up to 30 seconds while the status does not change
+func DefaultWatchOptions() *WatchOptions {
+	return &WatchOptions{
+		pollInterval: time.Second,
+		maxInterval:  30 * time.Second,
+		backoff:      1.5,
+		jitter:       0.1,
+	}
+}
+
+// WithPollInterval sets the interval between polls, it is restored every time the status changes
+func WithPollInterval(interval time.Duration) func(*WatchOptions) {
+	return func(o *WatchOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// WithPollBackoff multiplies the interval by factor after every poll without changes, up to maxInterval
+func WithPollBackoff(factor float64, maxInterval time.Duration) func(*WatchOptions) {
+	return func(o *WatchOptions) {
+		o.backoff = factor
+		o.maxInterval = maxInterval
+	}
+}
+
+// WithPollJitter randomizes every interval by +/- fraction of it, so many watchers don't poll at once
+func WithPollJitter(fraction float64) func(*WatchOptions) {
+	return func(o *WatchOptions) {
+		o.jitter = fraction
+	}
+}
+
+// WithStatusCallback calls fn for every status transition
+func WithStatusCallback(fn func(TransactionStatusChange)) func(*WatchOptions) {
+	return func(o *WatchOptions) {
+		o.onChange = fn
+	}
+}
+
+// WithStatusChannel sends every status transition to ch, the watcher blocks until ch is read or ctx is done
+func WithStatusChannel(ch chan<- TransactionStatusChange) func(*WatchOptions) {
+	return func(o *WatchOptions) {
+		o.changes = ch
+	}
+}
+
+// IsTerminal reports whether the transaction can't change its status anymore
+func (s TransactionStatus) IsTerminal() bool {
+	switch s {
+	case TransactionStatusCompleted,
+		TransactionStatusFailed,
+		TransactionStatusRejected,
+		TransactionStatusCancelled,
+		TransactionStatusBlocked,
+		TransactionStatusTimeout,
+		TransactionStatusPartiallyCompleted:
+		return true
+	}
+
+	return false
+}
+
+// TransactionWatcher polls transactions until they reach terminal status
+type TransactionWatcher struct {
+	sdk  *FireblocksSDK
+	opts []func(*WatchOptions)
+}
+
+// NewTransactionWatcher Creates watcher on top of client, opts are applied to every watched transaction
+func NewTransactionWatcher(client IAPIClient, opts ...func(*WatchOptions)) *TransactionWatcher {
+	return &TransactionWatcher{&FireblocksSDK{client: newContextClient(client)}, opts}
+}
+
+// WaitForTransaction blocks until the transaction reaches terminal status and returns it
+func (sdk *FireblocksSDK) WaitForTransaction(ctx context.Context, txID string, opts ...func(*WatchOptions)) (*TransactionResponse, error) {
+	return NewTransactionWatcher(sdk.client, opts...).Wait(ctx, txID)
+}
+
+// Wait blocks until the transaction reaches terminal status and returns it,
+// polling goes on after 429 and 5xx responses and stops on other errors or when ctx is done
+func (w *TransactionWatcher) Wait(ctx context.Context, txID string, opts ...func(*WatchOptions)) (*TransactionResponse, error) {
+	o := DefaultWatchOptions()
+	for _, opt := range w.opts {
+		opt(o)
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var (
+		previous *TransactionResponse
+		interval = o.pollInterval
+	)
+
+	for {
+		tx, err := w.sdk.GetTransactionByIDWithContext(ctx, txID)
+		if err != nil {
+			wait, ok := retryablePollError(err)
+			if !ok {
+				return previous, errors.Wrapf(err, "failed to poll transaction %s", txID)
+			}
+
+			// the transaction keeps moving while Fireblocks is throttling or unavailable
+			interval = o.nextInterval(interval)
+			if wait > interval {
+				interval = wait
+			}
+
+			if err := sleep(ctx, o.withJitter(interval)); err != nil {
+				return previous, err
+			}
+
+			continue
+		}
+
+		if previous == nil || previous.Status != tx.Status || previous.SubStatus != tx.SubStatus {
+			change := TransactionStatusChange{TxID: txID, Status: tx.Status, SubStatus: tx.SubStatus, Transaction: tx}
+			if previous != nil {
+				change.PreviousStatus = previous.Status
+				change.PreviousSubStatus = previous.SubStatus
+			}
+
+			if err := o.notify(ctx, change); err != nil {
+				return tx, err
+			}
+
+			interval = o.pollInterval
+		} else {
+			interval = o.nextInterval(interval)
+		}
+
+		previous = tx
+
+		if tx.Status.IsTerminal() {
+			return tx, nil
+		}
+
+		if err := sleep(ctx, o.withJitter(interval)); err != nil {
+			return tx, err
+		}
+	}
+}
+
+// retryablePollError reports whether polling continues after err, 429 and 5xx responses are temporary,
+// wait is Retry-After of the response
+func retryablePollError(err error) (wait time.Duration, ok bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return 0, false
+	}
+
+	if errors.Is(apiErr, ErrRateLimited) || apiErr.StatusCode >= http.StatusInternalServerError {
+		return apiErr.RetryAfter, true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WaitAll watches all transactions concurrently until every one of them reaches terminal status.
+// It returns the last known state of every transaction and the first error, which stops the other watchers.
+func (w *TransactionWatcher) WaitAll(ctx context.Context, txIDs []string, opts ...func(*WatchOptions)) (map[string]*TransactionResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		result   = make(map[string]*TransactionResponse, len(txIDs))
+	)
+
+	for _, txID := range txIDs {
+		wg.Add(1)
+
+		go func(txID string) {
+			defer wg.Done()
+
+			tx, err := w.Wait(ctx, txID, opts...)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if tx != nil {
+				result[txID] = tx
+			}
+
+			if err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}(txID)
+	}
+
+	wg.Wait()
+
+	return result, firstErr
+}
+
+func (o *WatchOptions) notify(ctx context.Context, change TransactionStatusChange) error {
+	if o.onChange != nil {
+		o.onChange(change)
+	}
+
+	if o.changes == nil {
+		return nil
+	}
+
+	select {
+	case o.changes <- change:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (o *WatchOptions) nextInterval(interval time.Duration) time.Duration {
+	if o.backoff <= 1 {
+		return interval
+	}
+
+	next := time.Duration(float64(interval) * o.backoff)
+	if o.maxInterval > 0 && next > o.maxInterval {
+		next = o.maxInterval
+	}
+
+	return next
+}
+
+func (o *WatchOptions) withJitter(interval time.Duration) time.Duration {
+	if o.jitter <= 0 {
+		return interval
+	}
+
+	delta := (rand.Float64()*2 - 1) * o.jitter * float64(interval) //nolint:gosec
+	return interval + time.Duration(delta)
+}
@@ -0,0 +1,209 @@
+package fireblocksdk_test
+
+import (
+	"context"
+	"encoding/json"
+	sdk "fireblocksdk"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWatcherSuite(t *testing.T) {
+	suite.Run(t, new(WatcherSuite))
+}
+
+type WatcherSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	client   sdk.IAPIClient
+	mu       sync.Mutex
+	history  map[string][]sdk.TransactionResponse
+	polls    map[string]int
+	failures map[string][]int
+}
+
+func (suite *WatcherSuite) SetupTest() {
+	suite.history = map[string][]sdk.TransactionResponse{}
+	suite.polls = map[string]int{}
+	suite.failures = map[string][]int{}
+
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+
+		txID := strings.TrimPrefix(r.URL.Path, "/v1/transactions/")
+		if failures := suite.failures[txID]; len(failures) > 0 {
+			suite.failures[txID] = failures[1:]
+			w.WriteHeader(failures[0])
+			return
+		}
+
+		history, ok := suite.history[txID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the last state is repeated once the history is over
+		poll := suite.polls[txID]
+		if poll >= len(history) {
+			poll = len(history) - 1
+		}
+		suite.polls[txID]++
+
+		_ = json.NewEncoder(w).Encode(history[poll])
+	}))
+
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey))
+	require.NoError(suite.T(), err)
+
+	suite.client = sdk.NewAPIClient(auth, suite.server.URL)
+}
+
+func (suite *WatcherSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *WatcherSuite) setHistory(txID string, states ...string) {
+	for _, state := range states {
+		status, subStatus, _ := strings.Cut(state, "/")
+		suite.history[txID] = append(suite.history[txID], sdk.TransactionResponse{
+			ID:        txID,
+			Status:    sdk.TransactionStatus(status),
+			SubStatus: subStatus,
+		})
+	}
+}
+
+func (suite *WatcherSuite) TestWaitReportsTransitions() {
+	suite.setHistory("tx-1",
+		"SUBMITTED",
+		"PENDING_SIGNATURE",
+		"PENDING_SIGNATURE",
+		"CONFIRMING/PENDING_BLOCKCHAIN_CONFIRMATIONS",
+		"COMPLETED/CONFIRMED",
+	)
+
+	var changes []sdk.TransactionStatusChange
+
+	fb, err := sdk.CreateSDK("apiKey", []byte(privateKey), "", sdk.WithAPIClient(suite.client))
+	require.NoError(suite.T(), err)
+
+	tx, err := fb.WaitForTransaction(
+		context.Background(),
+		"tx-1",
+		sdk.WithPollInterval(time.Millisecond),
+		sdk.WithStatusCallback(func(change sdk.TransactionStatusChange) {
+			changes = append(changes, change)
+		}),
+	)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.TransactionStatusCompleted, tx.Status)
+	require.Equal(suite.T(), 5, suite.polls["tx-1"])
+
+	require.Len(suite.T(), changes, 4)
+	require.Equal(suite.T(), sdk.TransactionStatus(""), changes[0].PreviousStatus)
+	require.Equal(suite.T(), sdk.TransactionStatusSubmitted, changes[0].Status)
+	require.Equal(suite.T(), sdk.TransactionStatusSubmitted, changes[1].PreviousStatus)
+	require.Equal(suite.T(), sdk.TransactionStatusPendingSignature, changes[1].Status)
+	require.Equal(suite.T(), "PENDING_BLOCKCHAIN_CONFIRMATIONS", changes[2].SubStatus)
+	require.Equal(suite.T(), "PENDING_BLOCKCHAIN_CONFIRMATIONS", changes[3].PreviousSubStatus)
+	require.Equal(suite.T(), "CONFIRMED", changes[3].SubStatus)
+}
+
+func (suite *WatcherSuite) TestWaitStopsOnContext() {
+	suite.setHistory("tx-1", "PENDING_AUTHORIZATION")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	watcher := sdk.NewTransactionWatcher(suite.client, sdk.WithPollInterval(5*time.Millisecond), sdk.WithPollJitter(0.5))
+
+	tx, err := watcher.Wait(ctx, "tx-1")
+	require.ErrorIs(suite.T(), err, context.DeadlineExceeded)
+	require.Equal(suite.T(), sdk.TransactionStatusPendingAuthorization, tx.Status)
+}
+
+func (suite *WatcherSuite) TestWaitFailsForUnknownTransaction() {
+	watcher := sdk.NewTransactionWatcher(suite.client)
+
+	_, err := watcher.Wait(context.Background(), "unknown")
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *WatcherSuite) TestWaitKeepsPollingOnTemporaryErrors() {
+	suite.setHistory("tx-1", "SUBMITTED", "COMPLETED")
+	suite.failures["tx-1"] = []int{http.StatusTooManyRequests, http.StatusBadGateway}
+
+	auth, err := sdk.NewAuthProvider("apiKey", []byte(privateKey))
+	require.NoError(suite.T(), err)
+
+	client := sdk.NewAPIClient(auth, suite.server.URL, func(c *sdk.APIClientConfig) {
+		c.RetryMax = 0
+	})
+
+	tx, err := sdk.NewTransactionWatcher(client, sdk.WithPollInterval(time.Millisecond)).Wait(context.Background(), "tx-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.TransactionStatusCompleted, tx.Status)
+	require.Equal(suite.T(), 2, suite.polls["tx-1"])
+}
+
+func (suite *WatcherSuite) TestWaitAllStopsOnFirstError() {
+	suite.setHistory("tx-1", "PENDING_AUTHORIZATION")
+
+	watcher := sdk.NewTransactionWatcher(suite.client, sdk.WithPollInterval(time.Millisecond))
+
+	// tx-1 never completes, WaitAll returns only because the error cancels its watcher
+	_, err := watcher.WaitAll(context.Background(), []string{"tx-1", "unknown"})
+	require.ErrorIs(suite.T(), err, sdk.ErrNotFound)
+}
+
+func (suite *WatcherSuite) TestWaitAllSendsChangesToChannel() {
+	suite.setHistory("tx-1", "SUBMITTED", "COMPLETED")
+	suite.setHistory("tx-2", "SUBMITTED", "QUEUED", "REJECTED")
+
+	changes := make(chan sdk.TransactionStatusChange, 10)
+	watcher := sdk.NewTransactionWatcher(
+		suite.client,
+		sdk.WithPollInterval(time.Millisecond),
+		sdk.WithPollBackoff(2, 4*time.Millisecond),
+		sdk.WithStatusChannel(changes),
+	)
+
+	result, err := watcher.WaitAll(context.Background(), []string{"tx-1", "tx-2"})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), sdk.TransactionStatusCompleted, result["tx-1"].Status)
+	require.Equal(suite.T(), sdk.TransactionStatusRejected, result["tx-2"].Status)
+
+	close(changes)
+
+	count := map[string]int{}
+	for change := range changes {
+		count[change.TxID]++
+	}
+
+	require.Equal(suite.T(), map[string]int{"tx-1": 2, "tx-2": 3}, count)
+}
+
+func (suite *WatcherSuite) TestTerminalStatuses() {
+	for _, status := range []sdk.TransactionStatus{
+		sdk.TransactionStatusCompleted,
+		sdk.TransactionStatusFailed,
+		sdk.TransactionStatusRejected,
+		sdk.TransactionStatusCancelled,
+		sdk.TransactionStatusBlocked,
+		sdk.TransactionStatusPartiallyCompleted,
+	} {
+		require.True(suite.T(), status.IsTerminal(), status)
+	}
+
+	require.False(suite.T(), sdk.TransactionStatusConfirming.IsTerminal())
+	require.False(suite.T(), sdk.TransactionStatusCancelling.IsTerminal())
+}
@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	sdk "fireblocksdk"
+
+	"github.com/pkg/errors"
+)
+
+type EventType string
+
+const (
+	TransactionCreated               EventType = "TRANSACTION_CREATED"
+	TransactionStatusUpdated         EventType = "TRANSACTION_STATUS_UPDATED"
+	TransactionApprovalStatusUpdated EventType = "TRANSACTION_APPROVAL_STATUS_UPDATED"
+	VaultAccountAdded                EventType = "VAULT_ACCOUNT_ADDED"
+	VaultAccountAssetAdded           EventType = "VAULT_ACCOUNT_ASSET_ADDED"
+	InternalWalletAssetAdded         EventType = "INTERNAL_WALLET_ASSET_ADDED"
+	ExternalWalletAssetAdded         EventType = "EXTERNAL_WALLET_ASSET_ADDED"
+	ExchangeAccountAdded             EventType = "EXCHANGE_ACCOUNT_ADDED"
+	FiatAccountAdded                 EventType = "FIAT_ACCOUNT_ADDED"
+	NetworkConnectionAdded           EventType = "NETWORK_CONNECTION_ADDED"
+)
+
+/*
+{
+    "type": "TRANSACTION_CREATED",
+    "tenantId": "string",
+    "timestamp": 1656425358000,
+    "data": {}
+}
+*/
+
+// Event is the envelope of every webhook sent by Fireblocks
+type Event struct {
+	Type      EventType       `json:"type"`
+	TenantID  string          `json:"tenantId"`
+	Timestamp int64           `json:"timestamp"` // Unix timestamp in milliseconds
+	Data      json.RawMessage `json:"data"`
+
+	// Payload is Data decoded according to Type:
+	// *fireblocksdk.TransactionResponse for transaction events,
+	// *TransactionApprovalStatus, *VaultAccountAddedData, *VaultAccountAssetAddedData, *WalletAssetAddedData,
+	// *ThirdPartyAccountAddedData for exchange and fiat accounts, *NetworkConnectionAddedData.
+	// Events of unknown types keep only the raw Data.
+	Payload interface{} `json:"-"`
+}
+
+type TransactionApprovalStatus struct {
+	ID                string `json:"id"`
+	Status            string `json:"status"`
+	SubStatus         string `json:"subStatus,omitempty"`
+	AuthorizationInfo struct {
+		AllowOperatorAsAuthorizer bool   `json:"allowOperatorAsAuthorizer"`
+		Logic                     string `json:"logic"`
+		Groups                    []struct {
+			Th    int               `json:"th"`
+			Users map[string]string `json:"users"`
+		} `json:"groups"`
+	} `json:"authorizationInfo"`
+}
+
+type VaultAccountAddedData struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	HiddenOnUI    bool                 `json:"hiddenOnUI,omitempty"`
+	AutoFuel      bool                 `json:"autoFuel,omitempty"`
+	CustomerRefID string               `json:"customerRefId,omitempty"`
+	Assets        []*sdk.AssetResponse `json:"assets,omitempty"`
+}
+
+type VaultAccountAssetAddedData struct {
+	AccountID   string `json:"accountId"`
+	AccountName string `json:"accountName"`
+	AssetID     string `json:"assetId"`
+}
+
+// WalletAssetAddedData is sent for internal and external wallets
+type WalletAssetAddedData struct {
+	AssetID        string `json:"assetId"`
+	WalletID       string `json:"walletId"`
+	Name           string `json:"name"`
+	Address        string `json:"address"`
+	Tag            string `json:"tag,omitempty"`
+	ActivationTime string `json:"activationTime,omitempty"`
+}
+
+// ThirdPartyAccountAddedData is sent for exchange and fiat accounts
+type ThirdPartyAccountAddedData struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	SubType string `json:"subType,omitempty"`
+}
+
+type NetworkConnectionAddedData struct {
+	ID              string                 `json:"id"`
+	LocalNetworkID  NetworkIDInfo          `json:"localNetworkId"`
+	RemoteNetworkID NetworkIDInfo          `json:"remoteNetworkId"`
+	RoutingPolicy   map[string]interface{} `json:"routingPolicy,omitempty"`
+}
+
+type NetworkIDInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// decode fills Payload with the typed Data
+func (e *Event) decode() error {
+	var payload interface{}
+
+	switch e.Type {
+	case TransactionCreated, TransactionStatusUpdated:
+		payload = &sdk.TransactionResponse{}
+	case TransactionApprovalStatusUpdated:
+		payload = &TransactionApprovalStatus{}
+	case VaultAccountAdded:
+		payload = &VaultAccountAddedData{}
+	case VaultAccountAssetAdded:
+		payload = &VaultAccountAssetAddedData{}
+	case InternalWalletAssetAdded, ExternalWalletAssetAdded:
+		payload = &WalletAssetAddedData{}
+	case ExchangeAccountAdded, FiatAccountAdded:
+		payload = &ThirdPartyAccountAddedData{}
+	case NetworkConnectionAdded:
+		payload = &NetworkConnectionAddedData{}
+	default:
+		return nil
+	}
+
+	if err := json.Unmarshal(e.Data, payload); err != nil {
+		return errors.Wrapf(err, "failed to decode %s data", e.Type)
+	}
+
+	e.Payload = payload
+
+	return nil
+}
+
+// Transaction returns Payload of transaction events
+func (e *Event) Transaction() (*sdk.TransactionResponse, bool) {
+	tx, ok := e.Payload.(*sdk.TransactionResponse)
+	return tx, ok
+}
@@ -0,0 +1,322 @@
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+const (
+	SignatureHeader = "Fireblocks-Signature"
+
+	defaultMaxBodySize  = 1 << 20
+	defaultReplayWindow = 24 * time.Hour
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleEvent       = errors.New("webhook event is too old")
+	ErrMissingTimestamp = errors.New("webhook event has no timestamp")
+	ErrEventInFlight    = errors.New("webhook event is being handled")
+)
+
+// EventHandlerFunc handles an event of the registered type, the returned error makes Fireblocks retry the webhook
+type EventHandlerFunc func(ctx context.Context, event *Event) error
+
+type HandlerConfig struct {
+	replayWindow time.Duration
+	maxBodySize  int64
+	timeProvider func() time.Time
+	onError      func(r *http.Request, err error)
+}
+
+// WithReplayWindow rejects events older than window and ignores repeated deliveries of events handled within it
+func WithReplayWindow(window time.Duration) func(*HandlerConfig) {
+	return func(c *HandlerConfig) {
+		c.replayWindow = window
+	}
+}
+
+// WithMaxBodySize limits the size of accepted webhooks
+func WithMaxBodySize(size int64) func(*HandlerConfig) {
+	return func(c *HandlerConfig) {
+		c.maxBodySize = size
+	}
+}
+
+// WithClock replaces time.Now used by replay protection
+func WithClock(now func() time.Time) func(*HandlerConfig) {
+	return func(c *HandlerConfig) {
+		c.timeProvider = now
+	}
+}
+
+// WithErrorHandler is called for every rejected webhook and every failed event handler
+func WithErrorHandler(fn func(r *http.Request, err error)) func(*HandlerConfig) {
+	return func(c *HandlerConfig) {
+		c.onError = fn
+	}
+}
+
+// Handler is http.Handler which verifies Fireblocks webhooks and dispatches them to registered handlers
+type Handler struct {
+	publicKey *rsa.PublicKey
+	cfg       *HandlerConfig
+
+	mu       sync.RWMutex
+	handlers map[EventType][]EventHandlerFunc
+	fallback []EventHandlerFunc
+
+	seenMu sync.Mutex
+	seen   map[string]seenState
+	order  []seenEvent // seen events in the order they were claimed, the oldest first
+}
+
+type seenState struct {
+	at   time.Time
+	done bool // handled successfully, false while the event is being handled
+}
+
+type seenEvent struct {
+	id string
+	at time.Time
+}
+
+// ParsePublicKey reads PEM encoded Fireblocks webhook public key
+func ParsePublicKey(publicKey []byte) (*rsa.PublicKey, error) {
+	key, err := jwt.ParseRSAPublicKeyFromPEM(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read public key")
+	}
+
+	return key, nil
+}
+
+// NewHandler Creates handler verifying webhooks with Fireblocks publicKey
+func NewHandler(publicKey *rsa.PublicKey, configs ...func(*HandlerConfig)) *Handler {
+	cfg := &HandlerConfig{
+		replayWindow: defaultReplayWindow,
+		maxBodySize:  defaultMaxBodySize,
+		timeProvider: time.Now,
+	}
+
+	for _, conf := range configs {
+		conf(cfg)
+	}
+
+	return &Handler{
+		publicKey: publicKey,
+		cfg:       cfg,
+		handlers:  map[EventType][]EventHandlerFunc{},
+		seen:      map[string]seenState{},
+	}
+}
+
+// On registers fn for events of eventType
+func (h *Handler) On(eventType EventType, fn EventHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// OnAny registers fn for events which have no handlers of their own
+func (h *Handler) OnAny(fn EventHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fallback = append(h.fallback, fn)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, h.cfg.maxBodySize))
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		h.fail(w, r, status, errors.Wrap(err, "failed to read body"))
+		return
+	}
+
+	event, err := h.Verify(body, r.Header.Get(SignatureHeader))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+
+		h.fail(w, r, status, err)
+		return
+	}
+
+	digest := sha256.Sum256(body)
+	id := hex.EncodeToString(digest[:])
+
+	// repeated delivery of a handled event is acknowledged without dispatching,
+	// delivery of an event being handled gets 409 so Fireblocks retries it in case the first delivery fails
+	if claimed, done := h.claim(id); !claimed {
+		if done {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			h.fail(w, r, http.StatusConflict, errors.Wrapf(ErrEventInFlight, "%s event", event.Type))
+		}
+		return
+	}
+
+	if err := h.dispatch(r.Context(), event); err != nil {
+		h.release(id)
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.complete(id)
+	w.WriteHeader(http.StatusOK)
+}
+
+// Verify checks signature of body and decodes the event, it does not apply replay protection
+func (h *Handler) Verify(body []byte, signature string) (*Event, error) {
+	if err := VerifySignature(h.publicKey, body, signature); err != nil {
+		return nil, err
+	}
+
+	event := &Event{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, errors.Wrap(err, "failed to decode event")
+	}
+
+	if h.cfg.replayWindow > 0 {
+		if event.Timestamp <= 0 {
+			return nil, ErrMissingTimestamp
+		}
+
+		created := time.UnixMilli(event.Timestamp)
+		if h.cfg.timeProvider().Sub(created) > h.cfg.replayWindow {
+			return nil, errors.Wrapf(ErrStaleEvent, "created at %s", created.UTC())
+		}
+	}
+
+	if err := event.decode(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// VerifySignature checks base64 encoded RSA SHA-512 signature of body
+func VerifySignature(publicKey *rsa.PublicKey, body []byte, signature string) error {
+	if signature == "" {
+		return errors.Wrap(ErrInvalidSignature, "signature is missing")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSignature, "signature is not base64")
+	}
+
+	digest := sha512.Sum512(body)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, digest[:], sig); err != nil {
+		return errors.Wrap(ErrInvalidSignature, err.Error())
+	}
+
+	return nil
+}
+
+func (h *Handler) dispatch(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	handlers := h.handlers[event.Type]
+	if len(handlers) == 0 {
+		handlers = h.fallback
+	}
+	h.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return errors.Wrapf(err, "failed to handle %s", event.Type)
+		}
+	}
+
+	return nil
+}
+
+// claim marks the event as being handled, it returns false when the event was seen within the replay window
+// and done when that event was handled successfully
+func (h *Handler) claim(id string) (claimed, done bool) {
+	if h.cfg.replayWindow <= 0 {
+		return true, false
+	}
+
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	now := h.cfg.timeProvider()
+	h.evict(now)
+
+	if state, ok := h.seen[id]; ok {
+		return false, state.done
+	}
+
+	h.seen[id] = seenState{at: now}
+	h.order = append(h.order, seenEvent{id, now})
+
+	return true, false
+}
+
+// complete marks the claimed event as handled, its repeated deliveries are acknowledged from now on
+func (h *Handler) complete(id string) {
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	if state, ok := h.seen[id]; ok {
+		state.done = true
+		h.seen[id] = state
+	}
+}
+
+// release forgets the event which failed to be handled, so its retry is dispatched again
+func (h *Handler) release(id string) {
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	delete(h.seen, id)
+}
+
+// evict forgets events older than the replay window, only the expired head of order is visited
+func (h *Handler) evict(now time.Time) {
+	for len(h.order) > 0 && now.Sub(h.order[0].at) > h.cfg.replayWindow {
+		oldest := h.order[0]
+		h.order = h.order[1:]
+
+		// the event may be released and claimed again later, its newer entry is kept
+		if state, ok := h.seen[oldest.id]; ok && state.at.Equal(oldest.at) {
+			delete(h.seen, oldest.id)
+		}
+	}
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.cfg.onError != nil {
+		h.cfg.onError(r, err)
+	}
+
+	w.WriteHeader(status)
+}
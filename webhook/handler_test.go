@@ -0,0 +1,265 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	sdk "fireblocksdk"
+	"fireblocksdk/webhook"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestHandlerSuite(t *testing.T) {
+	suite.Run(t, new(HandlerSuite))
+}
+
+type HandlerSuite struct {
+	suite.Suite
+	key     *rsa.PrivateKey
+	now     time.Time
+	handler *webhook.Handler
+	errMu   sync.Mutex
+	errors  []error
+}
+
+func (suite *HandlerSuite) SetupTest() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(suite.T(), err)
+
+	suite.key = key
+	suite.now = time.Unix(1656425358, 0)
+	suite.errors = nil
+	suite.handler = webhook.NewHandler(
+		&key.PublicKey,
+		webhook.WithClock(func() time.Time { return suite.now }),
+		webhook.WithErrorHandler(func(r *http.Request, err error) {
+			suite.errMu.Lock()
+			defer suite.errMu.Unlock()
+
+			suite.errors = append(suite.errors, err)
+		}),
+	)
+}
+
+func (suite *HandlerSuite) sign(body []byte) string {
+	digest := sha512.Sum512(body)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, suite.key, crypto.SHA512, digest[:])
+	require.NoError(suite.T(), err)
+
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func (suite *HandlerSuite) send(body []byte, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set(webhook.SignatureHeader, signature)
+
+	rec := httptest.NewRecorder()
+	suite.handler.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func (suite *HandlerSuite) event(eventType webhook.EventType, data string) []byte {
+	return []byte(fmt.Sprintf(`{"type":"%s","tenantId":"tenant","timestamp":%d,"data":%s}`, eventType, suite.now.UnixMilli(), data))
+}
+
+func (suite *HandlerSuite) TestDispatchesTypedTransaction() {
+	var received *sdk.TransactionResponse
+
+	suite.handler.On(webhook.TransactionStatusUpdated, func(ctx context.Context, event *webhook.Event) error {
+		tx, ok := event.Transaction()
+		require.True(suite.T(), ok)
+		received = tx
+		return nil
+	})
+
+	body := suite.event(webhook.TransactionStatusUpdated, `{"id":"tx-1","status":"COMPLETED","source":{"type":"VAULT_ACCOUNT","id":"0"}}`)
+
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, suite.sign(body)))
+	require.NotNil(suite.T(), received)
+	require.Equal(suite.T(), "tx-1", received.ID)
+	require.Equal(suite.T(), sdk.TransactionStatusCompleted, received.Status)
+	require.Equal(suite.T(), sdk.PeerTypeVaultAccount, received.Source.Type)
+}
+
+func (suite *HandlerSuite) TestDecodesVaultAccountAdded() {
+	var payload interface{}
+
+	suite.handler.OnAny(func(ctx context.Context, event *webhook.Event) error {
+		payload = event.Payload
+		return nil
+	})
+
+	body := suite.event(webhook.VaultAccountAdded, `{"id":"7","name":"treasury","assets":[{"id":"BTC","total":"1"}]}`)
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, suite.sign(body)))
+
+	account, ok := payload.(*webhook.VaultAccountAddedData)
+	require.True(suite.T(), ok)
+	require.Equal(suite.T(), "treasury", account.Name)
+	require.Equal(suite.T(), "1", account.Assets[0].Total)
+}
+
+func (suite *HandlerSuite) TestRejectsInvalidSignature() {
+	called := false
+	suite.handler.OnAny(func(ctx context.Context, event *webhook.Event) error {
+		called = true
+		return nil
+	})
+
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	signature := suite.sign(body)
+
+	tampered := bytes.Replace(body, []byte("tx-1"), []byte("tx-2"), 1)
+	require.Equal(suite.T(), http.StatusUnauthorized, suite.send(tampered, signature))
+	require.Equal(suite.T(), http.StatusUnauthorized, suite.send(body, ""))
+	require.Equal(suite.T(), http.StatusUnauthorized, suite.send(body, "not base64!"))
+	require.False(suite.T(), called)
+
+	require.Len(suite.T(), suite.errors, 3)
+	require.ErrorIs(suite.T(), suite.errors[0], webhook.ErrInvalidSignature)
+}
+
+func (suite *HandlerSuite) TestRejectsStaleEvent() {
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	suite.now = suite.now.Add(25 * time.Hour)
+
+	require.Equal(suite.T(), http.StatusBadRequest, suite.send(body, suite.sign(body)))
+	require.ErrorIs(suite.T(), suite.errors[0], webhook.ErrStaleEvent)
+}
+
+func (suite *HandlerSuite) TestIgnoresReplayedEvent() {
+	calls := 0
+	suite.handler.On(webhook.TransactionCreated, func(ctx context.Context, event *webhook.Event) error {
+		calls++
+		return nil
+	})
+
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	signature := suite.sign(body)
+
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, signature))
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, signature))
+	require.Equal(suite.T(), 1, calls)
+}
+
+func (suite *HandlerSuite) TestConcurrentDeliveriesAreDispatchedOnce() {
+	var calls int32
+	suite.handler.On(webhook.TransactionCreated, func(ctx context.Context, event *webhook.Event) error {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	signature := suite.sign(body)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			suite.Contains([]int{http.StatusOK, http.StatusConflict}, suite.send(body, signature))
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(suite.T(), int32(1), atomic.LoadInt32(&calls))
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, signature))
+	require.Equal(suite.T(), int32(1), atomic.LoadInt32(&calls))
+}
+
+func (suite *HandlerSuite) TestInFlightDuplicateIsRetried() {
+	started := make(chan struct{})
+	fail := make(chan error)
+
+	var calls int32
+	suite.handler.On(webhook.TransactionCreated, func(ctx context.Context, event *webhook.Event) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			return <-fail
+		}
+		return nil
+	})
+
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	signature := suite.sign(body)
+
+	first := make(chan int)
+	go func() { first <- suite.send(body, signature) }()
+	<-started
+
+	require.Equal(suite.T(), http.StatusConflict, suite.send(body, signature))
+	require.ErrorIs(suite.T(), suite.errors[0], webhook.ErrEventInFlight)
+
+	fail <- errors.New("database is down")
+	require.Equal(suite.T(), http.StatusInternalServerError, <-first)
+
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, signature))
+	require.Equal(suite.T(), int32(2), atomic.LoadInt32(&calls))
+}
+
+func (suite *HandlerSuite) TestRejectsEventWithoutTimestamp() {
+	body := []byte(`{"type":"TRANSACTION_CREATED","tenantId":"tenant","data":{"id":"tx-1"}}`)
+
+	require.Equal(suite.T(), http.StatusBadRequest, suite.send(body, suite.sign(body)))
+	require.ErrorIs(suite.T(), suite.errors[0], webhook.ErrMissingTimestamp)
+}
+
+func (suite *HandlerSuite) TestRejectsTooLargeBody() {
+	handler := webhook.NewHandler(&suite.key.PublicKey, webhook.WithMaxBodySize(16))
+
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set(webhook.SignatureHeader, suite.sign(body))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(suite.T(), http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func (suite *HandlerSuite) TestFailedHandlerAllowsRetry() {
+	calls := 0
+	suite.handler.On(webhook.TransactionCreated, func(ctx context.Context, event *webhook.Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	body := suite.event(webhook.TransactionCreated, `{"id":"tx-1"}`)
+	signature := suite.sign(body)
+
+	require.Equal(suite.T(), http.StatusInternalServerError, suite.send(body, signature))
+	require.Equal(suite.T(), http.StatusOK, suite.send(body, signature))
+	require.Equal(suite.T(), 2, calls)
+}
+
+func (suite *HandlerSuite) TestParsePublicKey() {
+	der, err := x509.MarshalPKIXPublicKey(&suite.key.PublicKey)
+	require.NoError(suite.T(), err)
+
+	key, err := webhook.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), key.Equal(&suite.key.PublicKey))
+
+	_, err = webhook.ParsePublicKey([]byte("fakeKey"))
+	require.Error(suite.T(), err)
+}